@@ -0,0 +1,213 @@
+package Slices
+
+import (
+	"errors"
+	"strconv"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// Direction is the direction of a dataflow analysis.
+type Direction int
+
+const (
+	// Forward propagates the facts from the predecessors to the successors
+	// (e.g., reaching definitions).
+	Forward Direction = iota
+
+	// Backward propagates the facts from the successors to the predecessors
+	// (e.g., liveness).
+	Backward
+)
+
+// String implements the fmt.Stringer interface.
+func (d Direction) String() string {
+	names := [...]string{
+		"forward",
+		"backward",
+	}
+
+	if d < 0 || int(d) >= len(names) {
+		return "Direction(" + strconv.Itoa(int(d)) + ")"
+	}
+
+	return names[d]
+}
+
+// DataflowProblem is a monotone dataflow problem over a graph of nodes of
+// type N with facts of type F.
+type DataflowProblem[N comparable, F any] struct {
+	// Nodes are the nodes of the graph. Their order is the initial order of
+	// the worklist (reversed for backward problems).
+	Nodes []N
+
+	// Direction is the direction of the analysis.
+	Direction Direction
+
+	// SuccsFn is the function that returns the successors of a node. Successors
+	// that are not in Nodes are ignored.
+	SuccsFn func(node N) []N
+
+	// InitFn is the function that returns the initial fact of a node; that is,
+	// the fact on entry (or on exit for backward problems) before joining the
+	// facts of its neighbors.
+	InitFn func(node N) F
+
+	// JoinFn is the function that joins two facts.
+	JoinFn func(a, b F) F
+
+	// TransferFn is the function that computes the fact on exit (or on entry
+	// for backward problems) of a node from its fact on entry (or on exit).
+	TransferFn func(node N, fact F) (F, error)
+
+	// EqualFn is the function that checks whether two facts are equal.
+	EqualFn func(a, b F) bool
+
+	// MaxIterations is the maximum number of transfer applications. If it is
+	// less than or equal to 0, there is no limit.
+	MaxIterations int
+}
+
+// DataflowResult is the result of a dataflow analysis.
+type DataflowResult[N comparable, F any] struct {
+	// In are the facts on entry of every node.
+	In map[N]F
+
+	// Out are the facts on exit of every node.
+	Out map[N]F
+
+	// Iterations is the number of transfer applications.
+	Iterations int
+}
+
+// Solve solves the dataflow problem with a worklist algorithm.
+//
+// Returns:
+//   - *DataflowResult[N, F]: The facts of every node. On error, the facts
+//     computed so far.
+//   - error: An error if the problem could not be solved.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If one of the functions is nil or if the
+//     direction is unknown.
+//   - *ErrMaxRounds: If the maximum number of iterations was reached before
+//     reaching a fixpoint.
+//   - any error returned by TransferFn.
+//
+// Behaviors:
+//   - A node is re-queued only when the fact of one of its neighbors changed.
+func (p *DataflowProblem[N, F]) Solve() (*DataflowResult[N, F], error) {
+	if p.SuccsFn == nil {
+		return nil, uc.NewErrNilParameter("SuccsFn")
+	} else if p.InitFn == nil {
+		return nil, uc.NewErrNilParameter("InitFn")
+	} else if p.JoinFn == nil {
+		return nil, uc.NewErrNilParameter("JoinFn")
+	} else if p.TransferFn == nil {
+		return nil, uc.NewErrNilParameter("TransferFn")
+	} else if p.EqualFn == nil {
+		return nil, uc.NewErrNilParameter("EqualFn")
+	} else if p.Direction < Forward || p.Direction > Backward {
+		return nil, uc.NewErrInvalidParameter("Direction", errors.New("unknown direction "+p.Direction.String()))
+	}
+
+	known := make(map[N]struct{}, len(p.Nodes))
+
+	for _, node := range p.Nodes {
+		known[node] = struct{}{}
+	}
+
+	succs := make(map[N][]N, len(p.Nodes))
+	preds := make(map[N][]N, len(p.Nodes))
+
+	for _, node := range p.Nodes {
+		for _, succ := range p.SuccsFn(node) {
+			_, ok := known[succ]
+			if !ok {
+				continue
+			}
+
+			succs[node] = append(succs[node], succ)
+			preds[succ] = append(preds[succ], node)
+		}
+	}
+
+	// sources are the neighbors whose facts are joined; targets are the
+	// neighbors to re-queue.
+	sources, targets := preds, succs
+
+	order := make([]N, len(p.Nodes))
+	copy(order, p.Nodes)
+
+	if p.Direction == Backward {
+		sources, targets = succs, preds
+
+		for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+			order[i], order[j] = order[j], order[i]
+		}
+	}
+
+	// before is the fact fed to TransferFn; after is the fact it returns.
+	before := make(map[N]F, len(p.Nodes))
+	after := make(map[N]F, len(p.Nodes))
+
+	result := &DataflowResult[N, F]{}
+
+	if p.Direction == Backward {
+		result.In, result.Out = after, before
+	} else {
+		result.In, result.Out = before, after
+	}
+
+	computed := make(map[N]bool, len(p.Nodes))
+	queued := make(map[N]bool, len(p.Nodes))
+
+	worklist := order
+
+	for _, node := range order {
+		queued[node] = true
+	}
+
+	for len(worklist) > 0 {
+		if p.MaxIterations > 0 && result.Iterations >= p.MaxIterations {
+			return result, NewErrMaxRounds(p.MaxIterations)
+		}
+
+		node := worklist[0]
+		worklist = worklist[1:]
+		queued[node] = false
+
+		fact := p.InitFn(node)
+
+		for _, src := range sources[node] {
+			if computed[src] {
+				fact = p.JoinFn(fact, after[src])
+			}
+		}
+
+		before[node] = fact
+
+		out, err := p.TransferFn(node, fact)
+		result.Iterations++
+
+		if err != nil {
+			return result, err
+		}
+
+		if computed[node] && p.EqualFn(after[node], out) {
+			continue
+		}
+
+		after[node] = out
+		computed[node] = true
+
+		for _, target := range targets[node] {
+			if !queued[target] {
+				queued[target] = true
+				worklist = append(worklist, target)
+			}
+		}
+	}
+
+	return result, nil
+}
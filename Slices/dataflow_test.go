@@ -0,0 +1,87 @@
+package Slices
+
+import (
+	"testing"
+)
+
+// TestDataflowLiveness solves the liveness of two variables, x and y, over the
+// following graph:
+//
+//	1: x = ...        -> 2
+//	2: y = x          -> 3
+//	3: if y goto 2    -> 2, 4
+//	4: return y
+func TestDataflowLiveness(t *testing.T) {
+	const (
+		x uint = 1 << iota
+		y
+	)
+
+	succs := map[int][]int{
+		1: {2},
+		2: {3},
+		3: {2, 4},
+	}
+
+	defs := map[int]uint{1: x, 2: y}
+	uses := map[int]uint{2: x, 3: y, 4: y}
+
+	problem := &DataflowProblem[int, uint]{
+		Nodes:     []int{1, 2, 3, 4},
+		Direction: Backward,
+		SuccsFn: func(node int) []int {
+			return succs[node]
+		},
+		InitFn: func(node int) uint {
+			return 0
+		},
+		JoinFn: func(a, b uint) uint {
+			return a | b
+		},
+		TransferFn: func(node int, fact uint) (uint, error) {
+			return uses[node] | (fact &^ defs[node]), nil
+		},
+		EqualFn: func(a, b uint) bool {
+			return a == b
+		},
+	}
+
+	result, err := problem.Solve()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	wantIn := map[int]uint{1: 0, 2: x, 3: x | y, 4: y}
+	wantOut := map[int]uint{1: x, 2: x | y, 3: x | y, 4: 0}
+
+	for _, node := range problem.Nodes {
+		if result.In[node] != wantIn[node] {
+			t.Errorf("node %d: expected live-in %b, got %b", node, wantIn[node], result.In[node])
+		}
+
+		if result.Out[node] != wantOut[node] {
+			t.Errorf("node %d: expected live-out %b, got %b", node, wantOut[node], result.Out[node])
+		}
+	}
+}
+
+// TestDataflowUnknownDirection checks that an unknown direction is rejected.
+func TestDataflowUnknownDirection(t *testing.T) {
+	problem := &DataflowProblem[int, int]{
+		Direction:  Direction(2),
+		SuccsFn:    func(node int) []int { return nil },
+		InitFn:     func(node int) int { return 0 },
+		JoinFn:     func(a, b int) int { return a },
+		TransferFn: func(node int, fact int) (int, error) { return fact, nil },
+		EqualFn:    func(a, b int) bool { return a == b },
+	}
+
+	_, err := problem.Solve()
+	if err == nil {
+		t.Fatalf("expected an error, got nil")
+	}
+
+	if got := Direction(2).String(); got != "Direction(2)" {
+		t.Errorf("expected %q, got %q", "Direction(2)", got)
+	}
+}
@@ -1,5 +1,10 @@
 package Slices
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // ErrLastNotFound is an error type for when the last element is not found.
 type ErrLastNotFound struct{}
 
@@ -17,3 +22,299 @@ func (e *ErrLastNotFound) Error() string {
 func NewErrLastNotFound() *ErrLastNotFound {
 	return &ErrLastNotFound{}
 }
+
+// ErrLengthMismatch is an error type for when zipped streams have different lengths.
+type ErrLengthMismatch struct {
+	// Index is the index of the first element that is missing from one of the streams.
+	Index int
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "streams have different lengths (mismatch at index <index>)".
+func (e *ErrLengthMismatch) Error() string {
+	return "streams have different lengths (mismatch at index " + strconv.Itoa(e.Index) + ")"
+}
+
+// NewErrLengthMismatch creates a new ErrLengthMismatch.
+//
+// Parameters:
+//   - index: The index of the first element that is missing from one of the streams.
+//
+// Returns:
+//   - *ErrLengthMismatch: The new ErrLengthMismatch.
+func NewErrLengthMismatch(index int) *ErrLengthMismatch {
+	return &ErrLengthMismatch{
+		Index: index,
+	}
+}
+
+// ErrElement is an error type for when the evaluation of an element fails.
+type ErrElement[T any] struct {
+	// Elem is the element whose evaluation failed.
+	Elem T
+
+	// Reason is the reason of the failure.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "evaluation of element failed: <reason>".
+func (e *ErrElement[T]) Error() string {
+	if e.Reason == nil {
+		return "evaluation of element failed"
+	}
+
+	return "evaluation of element failed: " + e.Reason.Error()
+}
+
+// Unwrap returns the reason of the failure.
+//
+// Returns:
+//   - error: The reason of the failure.
+func (e *ErrElement[T]) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrElement creates a new ErrElement.
+//
+// Parameters:
+//   - elem: The element whose evaluation failed.
+//   - reason: The reason of the failure.
+//
+// Returns:
+//   - *ErrElement[T]: The new ErrElement.
+func NewErrElement[T any](elem T, reason error) *ErrElement[T] {
+	return &ErrElement[T]{
+		Elem:   elem,
+		Reason: reason,
+	}
+}
+
+// ErrMaxRounds is an error type for when a loop reaches its maximum number of rounds.
+type ErrMaxRounds struct {
+	// Limit is the maximum number of rounds.
+	Limit int
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "maximum number of rounds (<limit>) reached".
+func (e *ErrMaxRounds) Error() string {
+	return "maximum number of rounds (" + strconv.Itoa(e.Limit) + ") reached"
+}
+
+// NewErrMaxRounds creates a new ErrMaxRounds.
+//
+// Parameters:
+//   - limit: The maximum number of rounds.
+//
+// Returns:
+//   - *ErrMaxRounds: The new ErrMaxRounds.
+func NewErrMaxRounds(limit int) *ErrMaxRounds {
+	return &ErrMaxRounds{
+		Limit: limit,
+	}
+}
+
+// ErrMaxElements is an error type for when a loop reaches its maximum number of elements.
+type ErrMaxElements struct {
+	// Limit is the maximum number of elements.
+	Limit int
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "maximum number of elements (<limit>) reached".
+func (e *ErrMaxElements) Error() string {
+	return "maximum number of elements (" + strconv.Itoa(e.Limit) + ") reached"
+}
+
+// NewErrMaxElements creates a new ErrMaxElements.
+//
+// Parameters:
+//   - limit: The maximum number of elements.
+//
+// Returns:
+//   - *ErrMaxElements: The new ErrMaxElements.
+func NewErrMaxElements(limit int) *ErrMaxElements {
+	return &ErrMaxElements{
+		Limit: limit,
+	}
+}
+
+// ErrAlreadySeen is an error type for when elements are dropped because an
+// element with the same key was already processed.
+type ErrAlreadySeen[T any] struct {
+	// Round is the round that produced the elements. 0 for the initial elements.
+	Round int
+
+	// Elems are the dropped elements.
+	Elems []T
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "<count> element(s) already seen in round <round>".
+func (e *ErrAlreadySeen[T]) Error() string {
+	return strconv.Itoa(len(e.Elems)) + " element(s) already seen in round " + strconv.Itoa(e.Round)
+}
+
+// NewErrAlreadySeen creates a new ErrAlreadySeen.
+//
+// Parameters:
+//   - round: The round that produced the elements. 0 for the initial elements.
+//   - elems: The dropped elements.
+//
+// Returns:
+//   - *ErrAlreadySeen[T]: The new ErrAlreadySeen.
+func NewErrAlreadySeen[T any](round int, elems []T) *ErrAlreadySeen[T] {
+	return &ErrAlreadySeen[T]{
+		Round: round,
+		Elems: elems,
+	}
+}
+
+// ErrUnexpectedType is an error type for when a value is not of the expected type.
+type ErrUnexpectedType struct {
+	// Value is the value of the unexpected type.
+	Value any
+
+	// Expected is the name of the expected type.
+	Expected string
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "expected value of type <expected>, got <type of value> instead".
+func (e *ErrUnexpectedType) Error() string {
+	return "expected value of type " + e.Expected + ", got " + fmt.Sprintf("%T", e.Value) + " instead"
+}
+
+// NewErrUnexpectedType creates a new ErrUnexpectedType.
+//
+// Parameters:
+//   - value: The value of the unexpected type.
+//   - expected: The name of the expected type.
+//
+// Returns:
+//   - *ErrUnexpectedType: The new ErrUnexpectedType.
+func NewErrUnexpectedType(value any, expected string) *ErrUnexpectedType {
+	return &ErrUnexpectedType{
+		Value:    value,
+		Expected: expected,
+	}
+}
+
+// ErrLimitReached is an error type for when a limit of an evaluation is reached.
+type ErrLimitReached struct {
+	// Kind is the kind of the limit.
+	Kind LimitKind
+
+	// Limit is the value of the limit.
+	Limit int
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "<kind> (<limit>) reached".
+func (e *ErrLimitReached) Error() string {
+	return e.Kind.String() + " (" + strconv.Itoa(e.Limit) + ") reached"
+}
+
+// NewErrLimitReached creates a new ErrLimitReached.
+//
+// Parameters:
+//   - kind: The kind of the limit.
+//   - limit: The value of the limit.
+//
+// Returns:
+//   - *ErrLimitReached: The new ErrLimitReached.
+func NewErrLimitReached(kind LimitKind, limit int) *ErrLimitReached {
+	return &ErrLimitReached{
+		Kind:  kind,
+		Limit: limit,
+	}
+}
+
+// ErrPath is an error type for when the evaluation of a path fails.
+type ErrPath[E any] struct {
+	// Operation is the name of the operation that failed (e.g., "eval").
+	Operation string
+
+	// Path is the path that triggered the error.
+	Path E
+
+	// Depth is the length of the path.
+	Depth int
+
+	// Reason is the reason of the failure.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "<operation> failed on path of depth <depth>: <reason>".
+func (e *ErrPath[E]) Error() string {
+	msg := e.Operation + " failed on path of depth " + strconv.Itoa(e.Depth)
+
+	if e.Reason == nil {
+		return msg
+	}
+
+	return msg + ": " + e.Reason.Error()
+}
+
+// Unwrap returns the reason of the failure.
+//
+// Returns:
+//   - error: The reason of the failure.
+func (e *ErrPath[E]) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrPath creates a new ErrPath.
+//
+// Parameters:
+//   - operation: The name of the operation that failed.
+//   - path: The path that triggered the error.
+//   - depth: The length of the path.
+//   - reason: The reason of the failure.
+//
+// Returns:
+//   - *ErrPath[E]: The new ErrPath.
+func NewErrPath[E any](operation string, path E, depth int, reason error) *ErrPath[E] {
+	return &ErrPath[E]{
+		Operation: operation,
+		Path:      path,
+		Depth:     depth,
+		Reason:    reason,
+	}
+}
+
+// ErrCycle is an error type for when a cycle is found where none is allowed.
+type ErrCycle[T any] struct {
+	// Elem is the element at which the cycle was detected.
+	Elem T
+}
+
+// Error implements the error interface.
+//
+// It returns the message: "cycle detected".
+func (e *ErrCycle[T]) Error() string {
+	return "cycle detected"
+}
+
+// NewErrCycle creates a new ErrCycle.
+//
+// Parameters:
+//   - elem: The element at which the cycle was detected.
+//
+// Returns:
+//   - *ErrCycle[T]: The new ErrCycle.
+func NewErrCycle[T any](elem T) *ErrCycle[T] {
+	return &ErrCycle[T]{
+		Elem: elem,
+	}
+}
@@ -0,0 +1,93 @@
+package Slices
+
+import (
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// FixpointReport is the report of a fixpoint computation.
+type FixpointReport struct {
+	// Iterations is the number of times the step function was applied.
+	Iterations int
+
+	// Converged is true if a fixpoint was reached.
+	Converged bool
+}
+
+// Fixpoint repeatedly applies a step function to a value until it stops changing.
+//
+// Parameters:
+//   - init: The initial value.
+//   - step: The step function.
+//   - equal: The function that checks whether two successive values are equal.
+//   - maxIter: The maximum number of iterations. If it is less than or equal to 0,
+//     there is no limit.
+//
+// Returns:
+//   - T: The fixpoint, or the last value if no fixpoint was reached.
+//   - *FixpointReport: The report of the computation. Never nil.
+//   - error: An error if the computation failed.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If step or equal is nil.
+//   - *ErrMaxRounds: If the maximum number of iterations was reached without converging.
+//   - any error returned by step.
+func Fixpoint[T any](init T, step uc.EvalOneFunc[T, T], equal func(a, b T) bool, maxIter int) (T, *FixpointReport, error) {
+	report := &FixpointReport{}
+
+	if step == nil {
+		return init, report, uc.NewErrNilParameter("step")
+	} else if equal == nil {
+		return init, report, uc.NewErrNilParameter("equal")
+	}
+
+	curr := init
+
+	for maxIter <= 0 || report.Iterations < maxIter {
+		next, err := step(curr)
+		report.Iterations++
+
+		if err != nil {
+			return curr, report, err
+		}
+
+		if equal(curr, next) {
+			report.Converged = true
+			return next, report, nil
+		}
+
+		curr = next
+	}
+
+	return curr, report, NewErrMaxRounds(maxIter)
+}
+
+// FixpointByKey is like Fixpoint but two successive values are equal if they
+// have the same key.
+//
+// Parameters:
+//   - init: The initial value.
+//   - step: The step function.
+//   - key: The function that returns the key (e.g., a hash) of a value.
+//   - maxIter: The maximum number of iterations. If it is less than or equal to 0,
+//     there is no limit.
+//
+// Returns:
+//   - T: The fixpoint, or the last value if no fixpoint was reached.
+//   - *FixpointReport: The report of the computation. Never nil.
+//   - error: An error if the computation failed.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If step or key is nil.
+//   - *ErrMaxRounds: If the maximum number of iterations was reached without converging.
+//   - any error returned by step.
+func FixpointByKey[T any, K comparable](init T, step uc.EvalOneFunc[T, T], key func(value T) K, maxIter int) (T, *FixpointReport, error) {
+	if key == nil {
+		return init, &FixpointReport{}, uc.NewErrNilParameter("key")
+	}
+
+	equal := func(a, b T) bool {
+		return key(a) == key(b)
+	}
+
+	return Fixpoint(init, step, equal, maxIter)
+}
@@ -0,0 +1,170 @@
+package Slices
+
+import (
+	"errors"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// IncrementalEvaluator is a leaf evaluator whose elements are pushed one at a
+// time instead of being pulled from the evaluater's iterator.
+type IncrementalEvaluator[A, T, E, R any] struct {
+	// ev is the underlying leaf evaluater.
+	ev LeafEvaluater[A, T, E, R]
+
+	// branches are the live branches.
+	branches []T
+
+	// index is the index of the next element.
+	index int
+}
+
+// NewIncrementalEvaluator creates a new IncrementalEvaluator.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - args: The arguments.
+//
+// Returns:
+//   - *IncrementalEvaluator[A, T, E, R]: The new IncrementalEvaluator.
+//   - error: An error if the initialization fails.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If elem or its evaluator is nil.
+//   - any error returned by the Init or InitMany method of the evaluator.
+//
+// Behaviors:
+//   - The iterator of the evaluator is never used.
+func NewIncrementalEvaluator[A, T, E, R any](elem LeafEvaluable[A, T, E, R], args []A) (*IncrementalEvaluator[A, T, E, R], error) {
+	if elem == nil {
+		return nil, uc.NewErrNilParameter("elem")
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return nil, uc.NewErrNilParameter("elem.Evaluator()")
+	}
+
+	branches, err := initBranches(ev, args)
+	if err != nil {
+		return nil, err
+	}
+
+	ie := &IncrementalEvaluator[A, T, E, R]{
+		ev:       ev,
+		branches: branches,
+		index:    0,
+	}
+
+	return ie, nil
+}
+
+// NewIncrementalEvaluatorFrom creates a new IncrementalEvaluator that restarts
+// from a saved set of branches.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - branches: The saved branches.
+//   - index: The index of the next element to be fed.
+//
+// Returns:
+//   - *IncrementalEvaluator[A, T, E, R]: The new IncrementalEvaluator.
+//   - error: An error of type *common.ErrInvalidParameter if elem or its
+//     evaluator is nil, or if index is negative.
+//
+// Behaviors:
+//   - Init is not called.
+func NewIncrementalEvaluatorFrom[A, T, E, R any](elem LeafEvaluable[A, T, E, R], branches []T, index int) (*IncrementalEvaluator[A, T, E, R], error) {
+	if elem == nil {
+		return nil, uc.NewErrNilParameter("elem")
+	} else if index < 0 {
+		return nil, uc.NewErrInvalidParameter("index", errors.New("value must be non-negative"))
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return nil, uc.NewErrNilParameter("elem.Evaluator()")
+	}
+
+	seed := make([]T, len(branches))
+	copy(seed, branches)
+
+	ie := &IncrementalEvaluator[A, T, E, R]{
+		ev:       ev,
+		branches: seed,
+		index:    index,
+	}
+
+	return ie, nil
+}
+
+// Feed evaluates the next element on all the live branches.
+//
+// Parameters:
+//   - elem: The element to evaluate.
+//
+// Returns:
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+//
+// Behaviors:
+//   - If there are no live branches, the element is ignored.
+//   - On error, the live branches are left untouched.
+func (ie *IncrementalEvaluator[A, T, E, R]) Feed(elem E) error {
+	if len(ie.branches) == 0 {
+		return nil
+	}
+
+	pair, err := ie.ev.Core(ie.index, elem)
+	if err != nil {
+		return err
+	}
+
+	var newBranches []T
+
+	for _, branch := range ie.branches {
+		tmp, err := ie.ev.Next(pair, branch)
+		if err != nil {
+			return err
+		}
+
+		if len(tmp) > 0 {
+			newBranches = append(newBranches, tmp...)
+		}
+	}
+
+	ie.branches = newBranches
+	ie.index++
+
+	return nil
+}
+
+// Branches returns the live branches.
+//
+// Returns:
+//   - []T: A copy of the live branches.
+func (ie *IncrementalEvaluator[A, T, E, R]) Branches() []T {
+	if len(ie.branches) == 0 {
+		return nil
+	}
+
+	branches := make([]T, len(ie.branches))
+	copy(branches, ie.branches)
+
+	return branches
+}
+
+// Index returns the number of elements evaluated so far.
+//
+// Returns:
+//   - int: The number of elements evaluated.
+func (ie *IncrementalEvaluator[A, T, E, R]) Index() int {
+	return ie.index
+}
+
+// IsDone checks whether there are no live branches left.
+//
+// Returns:
+//   - bool: True if there are no live branches, false otherwise.
+func (ie *IncrementalEvaluator[A, T, E, R]) IsDone() bool {
+	return len(ie.branches) == 0
+}
@@ -0,0 +1,246 @@
+package Slices
+
+import (
+	"iter"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// SliceLaster is a Laster backed by a slice.
+//
+// Copy runs in O(n) time.
+type SliceLaster[T any] struct {
+	// elems are the elements of the Laster.
+	elems []T
+}
+
+// From implements the Laster interface.
+//
+// Never returns an error.
+func (sl *SliceLaster[T]) From(elems []T) (Laster[T], error) {
+	return NewSliceLaster(elems), nil
+}
+
+// GetLast implements the Laster interface.
+func (sl *SliceLaster[T]) GetLast() (T, bool) {
+	if sl == nil || len(sl.elems) == 0 {
+		return *new(T), false
+	}
+
+	return sl.elems[len(sl.elems)-1], true
+}
+
+// Append implements the Laster interface.
+func (sl *SliceLaster[T]) Append(elem T) {
+	sl.elems = append(sl.elems, elem)
+}
+
+// Copy implements the common.Copier interface.
+func (sl *SliceLaster[T]) Copy() uc.Copier {
+	if sl == nil {
+		return &SliceLaster[T]{}
+	}
+
+	elems := make([]T, len(sl.elems))
+	copy(elems, sl.elems)
+
+	return &SliceLaster[T]{
+		elems: elems,
+	}
+}
+
+// Len returns the number of elements in the Laster.
+//
+// Returns:
+//   - int: The number of elements.
+func (sl *SliceLaster[T]) Len() int {
+	if sl == nil {
+		return 0
+	}
+
+	return len(sl.elems)
+}
+
+// Slice returns the elements of the Laster, from the first to the last.
+//
+// Returns:
+//   - []T: A copy of the elements.
+func (sl *SliceLaster[T]) Slice() []T {
+	if sl == nil || len(sl.elems) == 0 {
+		return nil
+	}
+
+	elems := make([]T, len(sl.elems))
+	copy(elems, sl.elems)
+
+	return elems
+}
+
+// Backward returns a sequence over the elements of the Laster, from the last
+// to the first.
+//
+// Returns:
+//   - iter.Seq[T]: The sequence of elements.
+func (sl *SliceLaster[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if sl == nil {
+			return
+		}
+
+		for i := len(sl.elems) - 1; i >= 0; i-- {
+			if !yield(sl.elems[i]) {
+				return
+			}
+		}
+	}
+}
+
+// NewSliceLaster creates a new SliceLaster.
+//
+// Parameters:
+//   - elems: The initial elements.
+//
+// Returns:
+//   - *SliceLaster[T]: The new SliceLaster. Never returns nil.
+func NewSliceLaster[T any](elems []T) *SliceLaster[T] {
+	sl := &SliceLaster[T]{
+		elems: make([]T, len(elems)),
+	}
+
+	copy(sl.elems, elems)
+
+	return sl
+}
+
+// pathNode is a node of a persistent path.
+type pathNode[T any] struct {
+	// value is the value of the node.
+	value T
+
+	// prev is the previous node, shared between the paths.
+	prev *pathNode[T]
+
+	// length is the number of nodes from the first node up to this one.
+	length int
+}
+
+// PathLaster is a Laster backed by a persistent linked path whose nodes are
+// shared between copies.
+//
+// Copy and Append run in O(1) time.
+type PathLaster[T any] struct {
+	// last is the last node of the path.
+	last *pathNode[T]
+}
+
+// From implements the Laster interface.
+//
+// Never returns an error.
+func (pl *PathLaster[T]) From(elems []T) (Laster[T], error) {
+	return NewPathLaster(elems), nil
+}
+
+// GetLast implements the Laster interface.
+func (pl *PathLaster[T]) GetLast() (T, bool) {
+	if pl == nil || pl.last == nil {
+		return *new(T), false
+	}
+
+	return pl.last.value, true
+}
+
+// Append implements the Laster interface.
+//
+// The nodes already in the path are not modified; thus, the copies of the
+// Laster are not affected.
+func (pl *PathLaster[T]) Append(elem T) {
+	length := 1
+
+	if pl.last != nil {
+		length = pl.last.length + 1
+	}
+
+	pl.last = &pathNode[T]{
+		value:  elem,
+		prev:   pl.last,
+		length: length,
+	}
+}
+
+// Copy implements the common.Copier interface.
+func (pl *PathLaster[T]) Copy() uc.Copier {
+	if pl == nil {
+		return &PathLaster[T]{}
+	}
+
+	return &PathLaster[T]{
+		last: pl.last,
+	}
+}
+
+// Len returns the number of elements in the Laster.
+//
+// Returns:
+//   - int: The number of elements.
+func (pl *PathLaster[T]) Len() int {
+	if pl == nil || pl.last == nil {
+		return 0
+	}
+
+	return pl.last.length
+}
+
+// Slice returns the elements of the Laster, from the first to the last.
+//
+// Returns:
+//   - []T: The elements. Runs in O(n) time.
+func (pl *PathLaster[T]) Slice() []T {
+	size := pl.Len()
+	if size == 0 {
+		return nil
+	}
+
+	elems := make([]T, size)
+
+	for node := pl.last; node != nil; node = node.prev {
+		elems[node.length-1] = node.value
+	}
+
+	return elems
+}
+
+// Backward returns a sequence over the elements of the Laster, from the last
+// to the first.
+//
+// Returns:
+//   - iter.Seq[T]: The sequence of elements. Each step runs in O(1) time.
+func (pl *PathLaster[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if pl == nil {
+			return
+		}
+
+		for node := pl.last; node != nil; node = node.prev {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// NewPathLaster creates a new PathLaster.
+//
+// Parameters:
+//   - elems: The initial elements.
+//
+// Returns:
+//   - *PathLaster[T]: The new PathLaster. Never returns nil.
+func NewPathLaster[T any](elems []T) *PathLaster[T] {
+	pl := &PathLaster[T]{}
+
+	for _, elem := range elems {
+		pl.Append(elem)
+	}
+
+	return pl
+}
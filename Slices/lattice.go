@@ -0,0 +1,286 @@
+package Slices
+
+import (
+	"iter"
+	"slices"
+)
+
+// LatticeEdge is a link from a node to the node of the previous step that
+// leads to it.
+type LatticeEdge[T any] struct {
+	// From is the node of the previous step. Nil for the edges of the roots.
+	From *LatticeNode[T]
+
+	// Value is the branch state reached through the edge.
+	Value T
+}
+
+// LatticeNode is a node of a lattice of branches.
+type LatticeNode[T any] struct {
+	// Step is the number of elements evaluated before reaching the node.
+	Step int
+
+	// Value is the branch state of the node; that is, the value of its first
+	// edge. The next step is evaluated from this value only.
+	Value T
+
+	// Edges are the links to the nodes of the previous step, at most one per
+	// node. A root has one edge without From per first branch merged into it.
+	Edges []LatticeEdge[T]
+}
+
+// Lattice is a compact representation of the branches of a leaf evaluation
+// where the common prefixes are shared.
+type Lattice[T any] struct {
+	// Roots are the nodes of the first branches.
+	Roots []*LatticeNode[T]
+
+	// Leaves are the nodes of the final branches.
+	Leaves []*LatticeNode[T]
+}
+
+// Paths returns a sequence over the paths of the lattice, from a root to a leaf.
+//
+// Returns:
+//   - iter.Seq[[]T]: The sequence of paths. Each path is a new slice.
+//
+// Behaviors:
+//   - The paths are enumerated lazily and in order of the leaves.
+//   - There is one path per sequence of edges from a root to a leaf. Each
+//     value of a path is the value of the edge taken; thus, two branches merged
+//     into one node still appear with their own values.
+func (l *Lattice[T]) Paths() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if l == nil {
+			return
+		}
+
+		var reversed []T
+
+		var walk func(node *LatticeNode[T]) bool
+
+		walk = func(node *LatticeNode[T]) bool {
+			for _, edge := range node.Edges {
+				reversed = append(reversed, edge.Value)
+
+				var ok bool
+
+				if edge.From == nil {
+					path := slices.Clone(reversed)
+					slices.Reverse(path)
+
+					ok = yield(path)
+				} else {
+					ok = walk(edge.From)
+				}
+
+				reversed = reversed[:len(reversed)-1]
+
+				if !ok {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		for _, leaf := range l.Leaves {
+			if !walk(leaf) {
+				return
+			}
+		}
+	}
+}
+
+// CountPaths returns the number of paths of the lattice without enumerating them.
+//
+// Returns:
+//   - int: The number of paths.
+func (l *Lattice[T]) CountPaths() int {
+	if l == nil {
+		return 0
+	}
+
+	counts := make(map[*LatticeNode[T]]int)
+
+	var count func(node *LatticeNode[T]) int
+
+	count = func(node *LatticeNode[T]) int {
+		c, ok := counts[node]
+		if ok {
+			return c
+		}
+
+		for _, edge := range node.Edges {
+			if edge.From == nil {
+				c++
+			} else {
+				c += count(edge.From)
+			}
+		}
+
+		counts[node] = c
+
+		return c
+	}
+
+	var total int
+
+	for _, leaf := range l.Leaves {
+		total += count(leaf)
+	}
+
+	return total
+}
+
+// EvaluateLattice performs a leaf evaluation with a loop and returns the
+// branches as a lattice.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - args: The arguments.
+//   - key: The function that returns the equivalence key of a branch. If nil,
+//     no nodes are shared within a step.
+//
+// Returns:
+//   - *Lattice[T]: The lattice of branches.
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+//
+// Behaviors:
+//   - Within a step, the branches with the same key are merged into one node
+//     that keeps one edge per parent node, each with the value of the first
+//     branch reached from that parent. See Lattice.Paths.
+//   - On error, the lattice built so far is returned.
+//   - If elem is nil, the function returns nil.
+func EvaluateLattice[A, T, E, R any, K comparable](elem LeafEvaluable[A, T, E, R], args []A, key KeyFunc[T, K]) (*Lattice[T], error) {
+	if elem == nil {
+		return nil, nil
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return nil, nil
+	}
+
+	firstBranches, err := initBranches(ev, args)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := newLatticeStep(key)
+
+	for _, branch := range firstBranches {
+		roots.add(branch, nil)
+	}
+
+	lattice := &Lattice[T]{
+		Roots:  roots.nodes,
+		Leaves: roots.nodes,
+	}
+
+	index := 0
+	iter := ev.Iterator()
+	defer stopIter(iter)
+
+	for len(lattice.Leaves) > 0 {
+		value, err := iter.Consume()
+		if err != nil {
+			break
+		}
+
+		pair, err := ev.Core(index, value)
+		if err != nil {
+			return lattice, err
+		}
+
+		step := newLatticeStep(key)
+
+		for _, node := range lattice.Leaves {
+			tmp, err := ev.Next(pair, node.Value)
+			if err != nil {
+				return lattice, err
+			}
+
+			for _, branch := range tmp {
+				step.add(branch, node)
+			}
+		}
+
+		lattice.Leaves = step.nodes
+		index++
+	}
+
+	return lattice, nil
+}
+
+// latticeStep is a helper for building the nodes of one step of a lattice.
+type latticeStep[T any, K comparable] struct {
+	// key is the function that returns the equivalence key of a branch.
+	key KeyFunc[T, K]
+
+	// indices maps the keys to the index of their node.
+	indices map[K]int
+
+	// nodes are the nodes of the step.
+	nodes []*LatticeNode[T]
+}
+
+// newLatticeStep creates a new latticeStep.
+//
+// Parameters:
+//   - key: The function that returns the equivalence key of a branch.
+//
+// Returns:
+//   - *latticeStep[T, K]: The new latticeStep.
+func newLatticeStep[T any, K comparable](key KeyFunc[T, K]) *latticeStep[T, K] {
+	return &latticeStep[T, K]{
+		key:     key,
+		indices: make(map[K]int),
+	}
+}
+
+// add adds a branch to the step.
+//
+// Parameters:
+//   - branch: The branch to add.
+//   - parent: The node from which the branch was reached. Nil for roots.
+//
+// Behaviors:
+//   - The branches of a parent must be added one after the other; this way, a
+//     second branch of the same parent merged into the same node is detected by
+//     looking at the last edge only.
+func (ls *latticeStep[T, K]) add(branch T, parent *LatticeNode[T]) {
+	edge := LatticeEdge[T]{
+		From:  parent,
+		Value: branch,
+	}
+
+	step := 0
+
+	if parent != nil {
+		step = parent.Step + 1
+	}
+
+	if ls.key != nil {
+		k := ls.key(branch)
+
+		idx, ok := ls.indices[k]
+		if ok {
+			node := ls.nodes[idx]
+
+			if parent == nil || node.Edges[len(node.Edges)-1].From != parent {
+				node.Edges = append(node.Edges, edge)
+			}
+
+			return
+		}
+
+		ls.indices[k] = len(ls.nodes)
+	}
+
+	ls.nodes = append(ls.nodes, &LatticeNode[T]{
+		Step:  step,
+		Value: branch,
+		Edges: []LatticeEdge[T]{edge},
+	})
+}
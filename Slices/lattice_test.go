@@ -0,0 +1,93 @@
+package Slices
+
+import (
+	"slices"
+	"testing"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// subsetEvaluator is a leaf evaluator whose branches are the subsequences of
+// its elements.
+type subsetEvaluator struct {
+	// elems are the elements to evaluate.
+	elems []rune
+}
+
+func (se *subsetEvaluator) Init(elems []rune) (string, error) {
+	return "", nil
+}
+
+func (se *subsetEvaluator) Core(index int, lpe rune) (*uc.Pair[rune, error], error) {
+	return &uc.Pair[rune, error]{First: lpe}, nil
+}
+
+func (se *subsetEvaluator) Next(pair *uc.Pair[rune, error], branch string) ([]string, error) {
+	return []string{branch, branch + string(pair.First)}, nil
+}
+
+func (se *subsetEvaluator) Iterator() uc.Iterater[rune] {
+	return uc.NewSimpleIterator(se.elems)
+}
+
+func (se *subsetEvaluator) Evaluator() LeafEvaluater[rune, string, rune, rune] {
+	return se
+}
+
+// TestLatticePaths checks that merged branches keep their own values.
+func TestLatticePaths(t *testing.T) {
+	elem := &subsetEvaluator{elems: []rune("ab")}
+
+	lattice, err := EvaluateLattice(elem, nil, func(branch string) int {
+		return len(branch)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := [][]string{
+		{"", "", ""},
+		{"", "", "b"},
+		{"", "a", "a"},
+		{"", "a", "ab"},
+	}
+
+	var got [][]string
+
+	for path := range lattice.Paths() {
+		got = append(got, path)
+	}
+
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("expected paths %q, got %q", want, got)
+	}
+
+	if count := lattice.CountPaths(); count != len(want) {
+		t.Errorf("expected %d paths, got %d", len(want), count)
+	}
+}
+
+// TestLatticeSameParent checks that the branches of one parent merged into the
+// same node are linked only once.
+func TestLatticeSameParent(t *testing.T) {
+	elem := &subsetEvaluator{elems: []rune("ab")}
+
+	lattice, err := EvaluateLattice(elem, nil, func(branch string) int {
+		return 0
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var got [][]string
+
+	for path := range lattice.Paths() {
+		got = append(got, path)
+	}
+
+	want := [][]string{{"", "", ""}}
+
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("expected paths %q, got %q", want, got)
+	}
+}
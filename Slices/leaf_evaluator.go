@@ -1,6 +1,8 @@
 package Slices
 
 import (
+	"errors"
+
 	uc "github.com/PlayerR9/lib_units/common"
 )
 
@@ -50,6 +52,79 @@ type LeafEvaluable[A, T, E, R any] interface {
 	Evaluator() LeafEvaluater[A, T, E, R]
 }
 
+// MultiIniter is an optional interface that a LeafEvaluater can implement
+// when the evaluation starts with more than one branch.
+type MultiIniter[A, T any] interface {
+	// InitMany is a function type that initializes the memory and the first branches.
+	//
+	// Parameters:
+	//   - elems: The elements.
+	//
+	// Returns:
+	//   - []T: The first branches.
+	//   - error: An error if the initialization fails.
+	InitMany(elems []A) ([]T, error)
+}
+
+// Stopper is an optional interface that the iterator of a LeafEvaluater can
+// implement when it holds resources (e.g., a pulled sequence) that must be
+// released once the evaluation no longer needs it.
+type Stopper interface {
+	// Stop releases the resources of the iterator. It must be safe to call
+	// more than once.
+	Stop()
+}
+
+// stopIter stops an iterator if it implements Stopper.
+//
+// Parameters:
+//   - iter: The iterator to stop.
+func stopIter[E any](iter uc.Iterater[E]) {
+	s, ok := iter.(Stopper)
+	if ok {
+		s.Stop()
+	}
+}
+
+// initer is the part of a leaf evaluater that initializes the first branch.
+type initer[A, T any] interface {
+	// Init is a function type that initializes the memory and the first branch.
+	//
+	// Parameters:
+	//   - elems: The elements.
+	//
+	// Returns:
+	//   - T: The first branch.
+	//   - error: An error if the initialization fails.
+	Init(elems []A) (T, error)
+}
+
+// initBranches initializes the first branches of a leaf evaluation.
+//
+// Parameters:
+//   - ev: The evaluater.
+//   - args: The arguments.
+//
+// Returns:
+//   - []T: The first branches.
+//   - error: An error if the initialization fails.
+//
+// Behaviors:
+//   - If ev implements MultiIniter, InitMany is used instead of Init.
+func initBranches[A, T any](ev initer[A, T], args []A) ([]T, error) {
+	mi, ok := ev.(MultiIniter[A, T])
+	if ok {
+		return mi.InitMany(args)
+	}
+
+	firstBranch, err := ev.Init(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return []T{firstBranch}, nil
+}
+
 // Evaluate performs a leaf evaluation with a loop.
 //
 // Parameters:
@@ -64,6 +139,8 @@ type LeafEvaluable[A, T, E, R any] interface {
 //   - The function performs a leaf evaluation with a loop.
 //   - The function returns the branches.
 //   - If le is nil, the function returns nil.
+//   - If the evaluator implements MultiIniter, the evaluation starts from all the
+//     branches returned by InitMany.
 func Evaluate[A, T, E, R any](elem LeafEvaluable[A, T, E, R], args []A) ([]T, error) {
 	if elem == nil {
 		return nil, nil
@@ -74,16 +151,83 @@ func Evaluate[A, T, E, R any](elem LeafEvaluable[A, T, E, R], args []A) ([]T, er
 		return nil, nil
 	}
 
-	firstBranch, err := ev.Init(args)
+	branches, err := initBranches(ev, args)
 	if err != nil {
 		return nil, err
 	}
 
-	branches := []T{firstBranch}
+	if len(branches) == 0 {
+		return branches, nil
+	}
+
+	iter := ev.Iterator()
+	defer stopIter(iter)
+
+	return evaluateLoop(ev, iter, branches, 0, nil)
+}
+
+// EvaluateFrom restarts a leaf evaluation from a saved set of branches.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - branches: The saved branches.
+//   - index: The index of the element from which to restart.
+//
+// Returns:
+//   - []T: The branches.
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If index is negative.
+//   - any error returned by the evaluator.
+//
+// Behaviors:
+//   - Init is not called; the first index elements of the iterator are skipped.
+//   - If elem is nil or branches is empty, the function returns branches.
+func EvaluateFrom[A, T, E, R any](elem LeafEvaluable[A, T, E, R], branches []T, index int) ([]T, error) {
+	if index < 0 {
+		return branches, uc.NewErrInvalidParameter("index", errors.New("value must be non-negative"))
+	}
+
+	if elem == nil || len(branches) == 0 {
+		return branches, nil
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return branches, nil
+	}
 
-	index := 0
 	iter := ev.Iterator()
+	defer stopIter(iter)
 
+	for i := 0; i < index; i++ {
+		_, err := iter.Consume()
+		if err != nil {
+			return branches, nil
+		}
+	}
+
+	seed := make([]T, len(branches))
+	copy(seed, branches)
+
+	return evaluateLoop(ev, iter, seed, index, nil)
+}
+
+// evaluateLoop performs the loop of a leaf evaluation.
+//
+// Parameters:
+//   - ev: The leaf evaluater.
+//   - iter: The iterator over the remaining elements.
+//   - branches: The current branches.
+//   - index: The index of the next element.
+//   - reduce: The function applied to the new branches after each step. If nil,
+//     the new branches are kept as is.
+//
+// Returns:
+//   - []T: The branches.
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+func evaluateLoop[A, T, E, R any](ev LeafEvaluater[A, T, E, R], iter uc.Iterater[E], branches []T, index int, reduce func(branches []T) ([]T, error)) ([]T, error) {
 	for {
 		value, err := iter.Consume()
 		if err != nil {
@@ -108,6 +252,13 @@ func Evaluate[A, T, E, R any](elem LeafEvaluable[A, T, E, R], args []A) ([]T, er
 			}
 		}
 
+		if reduce != nil && len(newBranches) > 0 {
+			newBranches, err = reduce(newBranches)
+			if err != nil {
+				return branches, err
+			}
+		}
+
 		if len(newBranches) == 0 {
 			return newBranches, nil
 		}
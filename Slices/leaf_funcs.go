@@ -0,0 +1,229 @@
+package Slices
+
+import (
+	"iter"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// LeafFuncs is a function-based builder for leaf evaluators. It allows to
+// perform a leaf evaluation without declaring a type that implements
+// LeafEvaluater.
+type LeafFuncs[A, T, E, R any] struct {
+	// InitFn is the function that initializes the memory and the first branch.
+	InitFn func(elems []A) (T, error)
+
+	// InitManyFn is the optional function that initializes the memory and the
+	// first branches. If not nil, it is used instead of InitFn (see MultiIniter).
+	InitManyFn func(elems []A) ([]T, error)
+
+	// CoreFn is the function that performs the core evaluation.
+	CoreFn func(index int, lpe E) (*uc.Pair[R, error], error)
+
+	// NextFn is the function that performs the next evaluation.
+	NextFn func(pair *uc.Pair[R, error], branch T) ([]T, error)
+}
+
+// FromSlice creates a leaf evaluable over a slice of elements.
+//
+// Parameters:
+//   - elems: The elements to evaluate.
+//
+// Returns:
+//   - LeafEvaluable[A, T, E, R]: The leaf evaluable.
+//   - error: An error of type *common.ErrInvalidParameter if
+//     InitFn, CoreFn or NextFn are nil.
+func (lf *LeafFuncs[A, T, E, R]) FromSlice(elems []E) (LeafEvaluable[A, T, E, R], error) {
+	err := lf.check()
+	if err != nil {
+		return nil, err
+	}
+
+	return lf.build(func() uc.Iterater[E] {
+		return uc.NewSimpleIterator(elems)
+	}), nil
+}
+
+// FromSeq creates a leaf evaluable over a sequence of elements.
+//
+// Parameters:
+//   - seq: The sequence of elements to evaluate.
+//
+// Returns:
+//   - LeafEvaluable[A, T, E, R]: The leaf evaluable.
+//   - error: An error of type *common.ErrInvalidParameter if
+//     InitFn, CoreFn or NextFn are nil.
+//
+// Behaviors:
+//   - The sequence is pulled lazily, one element per step, and stopped as soon
+//     as the evaluation ends.
+//   - Each iterator (and each restart) ranges over seq again; thus, a
+//     single-use sequence is only seen by the first one.
+//   - If seq is nil, the evaluation is performed over no elements.
+func (lf *LeafFuncs[A, T, E, R]) FromSeq(seq iter.Seq[E]) (LeafEvaluable[A, T, E, R], error) {
+	err := lf.check()
+	if err != nil {
+		return nil, err
+	}
+
+	return lf.build(func() uc.Iterater[E] {
+		return &seqIterator[E]{
+			seq: seq,
+		}
+	}), nil
+}
+
+// build creates the leaf evaluable of the functions.
+//
+// Parameters:
+//   - iterFn: The function that returns a new iterator over the elements.
+//
+// Returns:
+//   - LeafEvaluable[A, T, E, R]: The leaf evaluable. It implements MultiIniter
+//     if InitManyFn is set.
+func (lf *LeafFuncs[A, T, E, R]) build(iterFn func() uc.Iterater[E]) LeafEvaluable[A, T, E, R] {
+	lfe := &leafFuncsEvaluator[A, T, E, R]{
+		funcs:  *lf,
+		iterFn: iterFn,
+	}
+
+	if lf.InitManyFn == nil {
+		return lfe
+	}
+
+	return &leafFuncsManyEvaluator[A, T, E, R]{
+		leafFuncsEvaluator: lfe,
+	}
+}
+
+// check checks that all the functions are set.
+//
+// Returns:
+//   - error: An error of type *common.ErrInvalidParameter if
+//     InitFn, CoreFn or NextFn are nil.
+func (lf *LeafFuncs[A, T, E, R]) check() error {
+	if lf.InitFn == nil {
+		return uc.NewErrNilParameter("InitFn")
+	} else if lf.CoreFn == nil {
+		return uc.NewErrNilParameter("CoreFn")
+	} else if lf.NextFn == nil {
+		return uc.NewErrNilParameter("NextFn")
+	}
+
+	return nil
+}
+
+// leafFuncsEvaluator is the leaf evaluater built by LeafFuncs.
+type leafFuncsEvaluator[A, T, E, R any] struct {
+	// funcs are the functions of the evaluator.
+	funcs LeafFuncs[A, T, E, R]
+
+	// iterFn is the function that returns a new iterator over the elements to
+	// evaluate.
+	iterFn func() uc.Iterater[E]
+}
+
+// Init implements the LeafEvaluater interface.
+func (lfe *leafFuncsEvaluator[A, T, E, R]) Init(elems []A) (T, error) {
+	return lfe.funcs.InitFn(elems)
+}
+
+// leafFuncsManyEvaluator is the leaf evaluater built by LeafFuncs when
+// InitManyFn is set.
+type leafFuncsManyEvaluator[A, T, E, R any] struct {
+	*leafFuncsEvaluator[A, T, E, R]
+}
+
+// InitMany implements the MultiIniter interface.
+func (lfme *leafFuncsManyEvaluator[A, T, E, R]) InitMany(elems []A) ([]T, error) {
+	return lfme.funcs.InitManyFn(elems)
+}
+
+// Evaluator implements the LeafEvaluable interface.
+//
+// It returns the evaluator itself.
+func (lfme *leafFuncsManyEvaluator[A, T, E, R]) Evaluator() LeafEvaluater[A, T, E, R] {
+	return lfme
+}
+
+// Core implements the LeafEvaluater interface.
+func (lfe *leafFuncsEvaluator[A, T, E, R]) Core(index int, lpe E) (*uc.Pair[R, error], error) {
+	return lfe.funcs.CoreFn(index, lpe)
+}
+
+// Next implements the LeafEvaluater interface.
+func (lfe *leafFuncsEvaluator[A, T, E, R]) Next(pair *uc.Pair[R, error], branch T) ([]T, error) {
+	return lfe.funcs.NextFn(pair, branch)
+}
+
+// Iterator implements the common.Iterable interface.
+func (lfe *leafFuncsEvaluator[A, T, E, R]) Iterator() uc.Iterater[E] {
+	return lfe.iterFn()
+}
+
+// Evaluator implements the LeafEvaluable interface.
+//
+// It returns the evaluator itself.
+func (lfe *leafFuncsEvaluator[A, T, E, R]) Evaluator() LeafEvaluater[A, T, E, R] {
+	return lfe
+}
+
+// seqIterator is an iterator that pulls the elements of a sequence lazily.
+type seqIterator[E any] struct {
+	// seq is the sequence of elements.
+	seq iter.Seq[E]
+
+	// next returns the next element of the pulled sequence. Nil if the
+	// sequence is not being pulled.
+	next func() (E, bool)
+
+	// stop stops the pulled sequence.
+	stop func()
+
+	// done is true if the sequence is exhausted.
+	done bool
+}
+
+// Consume implements the common.Iterater interface.
+//
+// Errors:
+//   - *common.ErrExhaustedIter: If the sequence is exhausted.
+func (si *seqIterator[E]) Consume() (E, error) {
+	if si.done || si.seq == nil {
+		return *new(E), uc.NewErrExhaustedIter()
+	}
+
+	if si.next == nil {
+		si.next, si.stop = iter.Pull(si.seq)
+	}
+
+	elem, ok := si.next()
+	if !ok {
+		si.Stop()
+		si.done = true
+
+		return *new(E), uc.NewErrExhaustedIter()
+	}
+
+	return elem, nil
+}
+
+// Restart implements the common.Iterater interface.
+//
+// The sequence is ranged over again from the start.
+func (si *seqIterator[E]) Restart() {
+	si.Stop()
+	si.done = false
+}
+
+// Stop implements the Stopper interface.
+func (si *seqIterator[E]) Stop() {
+	if si.stop == nil {
+		return
+	}
+
+	si.stop()
+
+	si.next = nil
+	si.stop = nil
+}
@@ -0,0 +1,99 @@
+package Slices
+
+import (
+	"testing"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// TestFromSeqLazy checks that FromSeq pulls an infinite sequence lazily and
+// stops it once every branch is dead.
+func TestFromSeqLazy(t *testing.T) {
+	var pulled int
+	var stopped bool
+
+	naturals := func(yield func(int) bool) {
+		defer func() {
+			stopped = true
+		}()
+
+		for i := 0; ; i++ {
+			pulled++
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	lf := &LeafFuncs[int, int, int, int]{
+		InitFn: func(elems []int) (int, error) {
+			return 0, nil
+		},
+		CoreFn: func(index int, lpe int) (*uc.Pair[int, error], error) {
+			return &uc.Pair[int, error]{First: lpe}, nil
+		},
+		NextFn: func(pair *uc.Pair[int, error], branch int) ([]int, error) {
+			if pair.First >= 3 {
+				return nil, nil
+			}
+
+			return []int{branch + pair.First}, nil
+		},
+	}
+
+	elem, err := lf.FromSeq(naturals)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	branches, err := Evaluate(elem, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(branches) != 0 {
+		t.Errorf("expected no branch, got %v", branches)
+	}
+
+	if pulled != 4 {
+		t.Errorf("expected 4 pulled elements, got %d", pulled)
+	}
+
+	if !stopped {
+		t.Errorf("expected the sequence to be stopped")
+	}
+}
+
+// TestLeafFuncsInitMany checks that InitManyFn starts the evaluation from
+// several branches.
+func TestLeafFuncsInitMany(t *testing.T) {
+	lf := &LeafFuncs[int, int, int, int]{
+		InitFn: func(elems []int) (int, error) {
+			return 0, nil
+		},
+		InitManyFn: func(elems []int) ([]int, error) {
+			return []int{0, 10}, nil
+		},
+		CoreFn: func(index int, lpe int) (*uc.Pair[int, error], error) {
+			return &uc.Pair[int, error]{First: lpe}, nil
+		},
+		NextFn: func(pair *uc.Pair[int, error], branch int) ([]int, error) {
+			return []int{branch + pair.First}, nil
+		},
+	}
+
+	elem, err := lf.FromSlice([]int{1, 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	branches, err := Evaluate(elem, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(branches) != 2 || branches[0] != 3 || branches[1] != 13 {
+		t.Errorf("expected [3 13], got %v", branches)
+	}
+}
@@ -0,0 +1,204 @@
+package Slices
+
+import (
+	"errors"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// Window is a read-only view over the elements that follow the current one.
+type Window[E any] struct {
+	// elems are the upcoming elements.
+	elems []E
+}
+
+// Len returns the number of upcoming elements in the window.
+//
+// Returns:
+//   - int: The number of upcoming elements. Less than the requested lookahead
+//     near the end of the input.
+func (w *Window[E]) Len() int {
+	if w == nil {
+		return 0
+	}
+
+	return len(w.elems)
+}
+
+// At returns the upcoming element at the given offset.
+//
+// Parameters:
+//   - offset: The offset of the element, where 0 is the element right after
+//     the current one.
+//
+// Returns:
+//   - E: The element at the offset.
+//   - bool: True if the element exists, false otherwise.
+func (w *Window[E]) At(offset int) (E, bool) {
+	if w == nil || offset < 0 || offset >= len(w.elems) {
+		return *new(E), false
+	}
+
+	return w.elems[offset], true
+}
+
+// Slice returns a copy of the upcoming elements.
+//
+// Returns:
+//   - []E: The upcoming elements.
+func (w *Window[E]) Slice() []E {
+	if w == nil || len(w.elems) == 0 {
+		return nil
+	}
+
+	elems := make([]E, len(w.elems))
+	copy(elems, w.elems)
+
+	return elems
+}
+
+// LookaheadLeafEvaluater is an interface that represents a leaf evaluater whose
+// Core method can see the upcoming elements.
+type LookaheadLeafEvaluater[A, T, E, R any] interface {
+	// Init is a function type that initializes the memory and the first branch.
+	//
+	// Parameters:
+	//   - elems: The elements.
+	//
+	// Returns:
+	//   - T: The first branch.
+	//   - error: An error if the initialization fails.
+	Init(elems []A) (T, error)
+
+	// Core is a function type that performs the core evaluation.
+	//
+	// Parameters:
+	//   - index: The index of the loop element.
+	//   - lpe: The loop element.
+	//   - ahead: The upcoming elements. Never nil.
+	//
+	// Returns:
+	//   - *uc.Pair[R, error]: The result and an error.
+	//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+	Core(index int, lpe E, ahead *Window[E]) (*uc.Pair[R, error], error)
+
+	// Next is a function type that performs the next evaluation.
+	//
+	// Parameters:
+	//   - pair: The result and an error.
+	//   - branch: The current branch.
+	//
+	// Returns:
+	//   - []T: The new branches.
+	//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+	Next(pair *uc.Pair[R, error], branch T) ([]T, error)
+
+	uc.Iterable[E]
+}
+
+// LookaheadLeafEvaluable is an interface that represents a lookahead leaf evaluable.
+type LookaheadLeafEvaluable[A, T, E, R any] interface {
+	// Evaluator is a function type that returns the lookahead leaf evaluator.
+	//
+	// Returns:
+	//   - LookaheadLeafEvaluater[A, T, E, R]: The lookahead leaf evaluator.
+	Evaluator() LookaheadLeafEvaluater[A, T, E, R]
+}
+
+// EvaluateLookahead performs a leaf evaluation with a loop where Core receives
+// up to k upcoming elements.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - args: The arguments.
+//   - k: The number of upcoming elements to look ahead.
+//
+// Returns:
+//   - []T: The branches.
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If k is negative.
+//   - any error returned by the evaluator.
+//
+// Behaviors:
+//   - The iterator is buffered so that it is consumed only once.
+//   - If the evaluator implements MultiIniter, the evaluation starts from all the
+//     branches returned by InitMany.
+//   - If elem is nil, the function returns nil.
+func EvaluateLookahead[A, T, E, R any](elem LookaheadLeafEvaluable[A, T, E, R], args []A, k int) ([]T, error) {
+	if k < 0 {
+		return nil, uc.NewErrInvalidParameter("k", errors.New("value must be non-negative"))
+	}
+
+	if elem == nil {
+		return nil, nil
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return nil, nil
+	}
+
+	branches, err := initBranches(ev, args)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := ev.Iterator()
+	defer stopIter(iter)
+
+	// buffer holds the current element followed by the upcoming ones.
+	var buffer []E
+
+	exhausted := false
+
+	fill := func() {
+		for !exhausted && len(buffer) < k+1 {
+			value, err := iter.Consume()
+			if err != nil {
+				exhausted = true
+			} else {
+				buffer = append(buffer, value)
+			}
+		}
+	}
+
+	index := 0
+
+	for len(branches) > 0 {
+		fill()
+
+		if len(buffer) == 0 {
+			break
+		}
+
+		window := &Window[E]{
+			elems: buffer[1:],
+		}
+
+		pair, err := ev.Core(index, buffer[0], window)
+		if err != nil {
+			return branches, err
+		}
+
+		var newBranches []T
+
+		for _, branch := range branches {
+			tmp, err := ev.Next(pair, branch)
+			if err != nil {
+				return branches, err
+			}
+
+			if len(tmp) > 0 {
+				newBranches = append(newBranches, tmp...)
+			}
+		}
+
+		branches = newBranches
+		buffer = buffer[1:]
+		index++
+	}
+
+	return branches, nil
+}
@@ -0,0 +1,136 @@
+package Slices
+
+import (
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// KeyFunc is a function that returns the equivalence key of a branch.
+//
+// Parameters:
+//   - branch: The branch.
+//
+// Returns:
+//   - K: The key of the branch. Branches with the same key are equivalent for
+//     the rest of the evaluation.
+type KeyFunc[T any, K comparable] func(branch T) K
+
+// MergeFunc is a function that merges two equivalent branches.
+//
+// Parameters:
+//   - first: The branch that was found first.
+//   - second: The branch that was found second.
+//
+// Returns:
+//   - T: The merged branch.
+//   - error: An error if the branches could not be merged.
+type MergeFunc[T any] func(first, second T) (T, error)
+
+// MergeBranches collapses the equivalent branches into one.
+//
+// Parameters:
+//   - branches: The branches to merge.
+//   - key: The function that returns the equivalence key of a branch.
+//   - merge: The function that merges two equivalent branches.
+//
+// Returns:
+//   - []T: The merged branches, in order of first appearance of their key.
+//   - error: An error if the branches could not be merged.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If key or merge is nil.
+//   - any error returned by merge.
+func MergeBranches[T any, K comparable](branches []T, key KeyFunc[T, K], merge MergeFunc[T]) ([]T, error) {
+	if key == nil {
+		return nil, uc.NewErrNilParameter("key")
+	} else if merge == nil {
+		return nil, uc.NewErrNilParameter("merge")
+	}
+
+	if len(branches) < 2 {
+		return branches, nil
+	}
+
+	indices := make(map[K]int, len(branches))
+	merged := make([]T, 0, len(branches))
+
+	for _, branch := range branches {
+		k := key(branch)
+
+		idx, ok := indices[k]
+		if !ok {
+			indices[k] = len(merged)
+			merged = append(merged, branch)
+
+			continue
+		}
+
+		tmp, err := merge(merged[idx], branch)
+		if err != nil {
+			return nil, err
+		}
+
+		merged[idx] = tmp
+	}
+
+	return merged, nil
+}
+
+// EvaluateMerged performs a leaf evaluation with a loop where equivalent
+// branches are merged after each step.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - args: The arguments.
+//   - key: The function that returns the equivalence key of a branch.
+//   - merge: The function that merges two equivalent branches.
+//
+// Returns:
+//   - []T: The branches.
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If key or merge is nil.
+//   - any error returned by the evaluator or by merge.
+//
+// Behaviors:
+//   - The first branches are merged too.
+//   - If elem is nil, the function returns nil.
+func EvaluateMerged[A, T, E, R any, K comparable](elem LeafEvaluable[A, T, E, R], args []A, key KeyFunc[T, K], merge MergeFunc[T]) ([]T, error) {
+	if key == nil {
+		return nil, uc.NewErrNilParameter("key")
+	} else if merge == nil {
+		return nil, uc.NewErrNilParameter("merge")
+	}
+
+	if elem == nil {
+		return nil, nil
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return nil, nil
+	}
+
+	branches, err := initBranches(ev, args)
+	if err != nil {
+		return nil, err
+	}
+
+	reduce := func(branches []T) ([]T, error) {
+		return MergeBranches(branches, key, merge)
+	}
+
+	branches, err = reduce(branches)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(branches) == 0 {
+		return branches, nil
+	}
+
+	iter := ev.Iterator()
+	defer stopIter(iter)
+
+	return evaluateLoop(ev, iter, branches, 0, reduce)
+}
@@ -0,0 +1,239 @@
+package Slices
+
+import (
+	"cmp"
+	"slices"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// ScoredBranch is a branch together with its accumulated score.
+type ScoredBranch[T any] struct {
+	// Branch is the branch.
+	Branch T
+
+	// Score is the accumulated score of the branch.
+	Score float64
+}
+
+// NewScoredBranch creates a new ScoredBranch.
+//
+// Parameters:
+//   - branch: The branch.
+//   - score: The score of the branch.
+//
+// Returns:
+//   - *ScoredBranch[T]: The new ScoredBranch.
+func NewScoredBranch[T any](branch T, score float64) *ScoredBranch[T] {
+	return &ScoredBranch[T]{
+		Branch: branch,
+		Score:  score,
+	}
+}
+
+// ScoredLeafEvaluater is an interface that represents a leaf evaluater whose
+// branches are ranked by a score.
+type ScoredLeafEvaluater[A, T, E, R any] interface {
+	// Init is a function type that initializes the memory and the first branch.
+	//
+	// Parameters:
+	//   - elems: The elements.
+	//
+	// Returns:
+	//   - T: The first branch.
+	//   - error: An error if the initialization fails.
+	Init(elems []A) (T, error)
+
+	// Core is a function type that performs the core evaluation.
+	//
+	// Parameters:
+	//   - index: The index of the loop element.
+	//   - lpe: The loop element.
+	//
+	// Returns:
+	//   - *uc.Pair[R, error]: The result and an error.
+	//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+	Core(index int, lpe E) (*uc.Pair[R, error], error)
+
+	// Next is a function type that performs the next evaluation.
+	//
+	// Parameters:
+	//   - pair: The result and an error.
+	//   - branch: The current branch.
+	//
+	// Returns:
+	//   - []*ScoredBranch[T]: The new branches. Their score is the score of the
+	//     step (e.g., a log-probability or a cost) and not the accumulated one.
+	//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+	Next(pair *uc.Pair[R, error], branch T) ([]*ScoredBranch[T], error)
+
+	uc.Iterable[E]
+}
+
+// ScoredLeafEvaluable is an interface that represents a scored leaf evaluable.
+type ScoredLeafEvaluable[A, T, E, R any] interface {
+	// Evaluator is a function type that returns the scored leaf evaluator.
+	//
+	// Returns:
+	//   - ScoredLeafEvaluater[A, T, E, R]: The scored leaf evaluator.
+	Evaluator() ScoredLeafEvaluater[A, T, E, R]
+}
+
+// ScoreConfig is the configuration of a scored leaf evaluation.
+type ScoreConfig struct {
+	// Minimize is true if the scores are costs (lower is better). Otherwise,
+	// the scores are log-probabilities (higher is better).
+	Minimize bool
+
+	// UseThreshold is true if the branches must be pruned by Threshold.
+	UseThreshold bool
+
+	// Threshold is the worst accumulated score a branch can have without being
+	// discarded. Only used if UseThreshold is true.
+	Threshold float64
+
+	// TopN is the maximum number of branches kept after each step. If it is
+	// less than or equal to 0, all branches are kept.
+	TopN int
+}
+
+// better checks whether the score a is strictly better than the score b.
+//
+// Parameters:
+//   - a: The first score.
+//   - b: The second score.
+//
+// Returns:
+//   - bool: True if a is better than b, false otherwise.
+func (sc *ScoreConfig) better(a, b float64) bool {
+	if sc.Minimize {
+		return a < b
+	}
+
+	return a > b
+}
+
+// prune sorts the branches from best to worst and applies the threshold and
+// the top-N limit.
+//
+// Parameters:
+//   - sc: The configuration of the evaluation.
+//   - branches: The branches to prune.
+//
+// Returns:
+//   - []*ScoredBranch[T]: The pruned branches.
+func prune[T any](sc *ScoreConfig, branches []*ScoredBranch[T]) []*ScoredBranch[T] {
+	if sc.UseThreshold {
+		var kept []*ScoredBranch[T]
+
+		for _, branch := range branches {
+			if !sc.better(sc.Threshold, branch.Score) {
+				kept = append(kept, branch)
+			}
+		}
+
+		branches = kept
+	}
+
+	slices.SortStableFunc(branches, func(a, b *ScoredBranch[T]) int {
+		if sc.Minimize {
+			return cmp.Compare(a.Score, b.Score)
+		}
+
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	if sc.TopN > 0 && len(branches) > sc.TopN {
+		branches = branches[:sc.TopN]
+	}
+
+	return branches
+}
+
+// EvaluateScored performs a scored leaf evaluation with a loop.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - args: The arguments.
+//   - config: The configuration of the evaluation. If nil, the scores are treated
+//     as log-probabilities and no branch is pruned.
+//
+// Returns:
+//   - []*ScoredBranch[T]: The branches sorted from best to worst.
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+//
+// Behaviors:
+//   - The first branches have a score of 0. If the evaluator implements
+//     MultiIniter, they are the branches returned by InitMany.
+//   - The score of a new branch is the score of its parent plus the score returned by Next.
+//   - After each step, the branches are pruned according to the configuration.
+//   - If elem is nil, the function returns nil.
+func EvaluateScored[A, T, E, R any](elem ScoredLeafEvaluable[A, T, E, R], args []A, config *ScoreConfig) ([]*ScoredBranch[T], error) {
+	if elem == nil {
+		return nil, nil
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return nil, nil
+	}
+
+	if config == nil {
+		config = &ScoreConfig{}
+	}
+
+	firstBranches, err := initBranches(ev, args)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]*ScoredBranch[T], 0, len(firstBranches))
+
+	for _, branch := range firstBranches {
+		branches = append(branches, NewScoredBranch(branch, 0.0))
+	}
+
+	index := 0
+	iter := ev.Iterator()
+	defer stopIter(iter)
+
+	for {
+		value, err := iter.Consume()
+		if err != nil {
+			break
+		}
+
+		pair, err := ev.Core(index, value)
+		if err != nil {
+			return branches, err
+		}
+
+		var newBranches []*ScoredBranch[T]
+
+		for _, branch := range branches {
+			tmp, err := ev.Next(pair, branch.Branch)
+			if err != nil {
+				return branches, err
+			}
+
+			for _, sb := range tmp {
+				if sb == nil {
+					continue
+				}
+
+				newBranches = append(newBranches, NewScoredBranch(sb.Branch, branch.Score+sb.Score))
+			}
+		}
+
+		newBranches = prune(config, newBranches)
+
+		if len(newBranches) == 0 {
+			return newBranches, nil
+		}
+
+		branches = newBranches
+		index++
+	}
+
+	return branches, nil
+}
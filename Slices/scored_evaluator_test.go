@@ -0,0 +1,55 @@
+package Slices
+
+import (
+	"testing"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// multiScoredEvaluator is a scored leaf evaluator that starts from two
+// branches and adds every element to them.
+type multiScoredEvaluator struct {
+	// elems are the elements to evaluate.
+	elems []int
+}
+
+func (mse *multiScoredEvaluator) Init(elems []int) (int, error) {
+	return 0, nil
+}
+
+func (mse *multiScoredEvaluator) InitMany(elems []int) ([]int, error) {
+	return []int{0, 10}, nil
+}
+
+func (mse *multiScoredEvaluator) Core(index int, lpe int) (*uc.Pair[int, error], error) {
+	return &uc.Pair[int, error]{First: lpe}, nil
+}
+
+func (mse *multiScoredEvaluator) Next(pair *uc.Pair[int, error], branch int) ([]*ScoredBranch[int], error) {
+	return []*ScoredBranch[int]{NewScoredBranch(branch+pair.First, float64(branch))}, nil
+}
+
+func (mse *multiScoredEvaluator) Iterator() uc.Iterater[int] {
+	return uc.NewSimpleIterator(mse.elems)
+}
+
+func (mse *multiScoredEvaluator) Evaluator() ScoredLeafEvaluater[int, int, int, int] {
+	return mse
+}
+
+// TestEvaluateScoredInitMany checks that EvaluateScored starts from every
+// branch returned by InitMany.
+func TestEvaluateScoredInitMany(t *testing.T) {
+	branches, err := EvaluateScored(&multiScoredEvaluator{elems: []int{1}}, nil, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(branches) != 2 {
+		t.Fatalf("expected 2 branches, got %d", len(branches))
+	}
+
+	if branches[0].Branch != 11 || branches[0].Score != 10 {
+		t.Errorf("expected the best branch to be 11 (10), got %d (%v)", branches[0].Branch, branches[0].Score)
+	}
+}
@@ -1,6 +1,11 @@
 package Slices
 
 import (
+	"errors"
+	"iter"
+	"runtime"
+	"sync"
+
 	uc "github.com/PlayerR9/lib_units/common"
 	slext "github.com/PlayerR9/lib_units/slices"
 )
@@ -50,3 +55,449 @@ func DoWhile[T any](todo []T, accept slext.PredicateFilter[T], f uc.EvalManyFunc
 
 	return done
 }
+
+// ErrorHandlerFunc is a function that handles the failure of an element.
+//
+// Parameters:
+//   - elem: The element whose evaluation failed.
+//   - err: The error returned by the evaluation function.
+//
+// Returns:
+//   - bool: True if the loop should continue, false if it should stop.
+type ErrorHandlerFunc[T any] func(elem T, err error) bool
+
+// DoWhileConfig is the configuration of DoWhileE.
+//
+// The key of the seen-set guard is not a field since it needs its own type
+// parameter; set it with SetKey after writing the literal.
+type DoWhileConfig[T any] struct {
+	// FailFast is true if the loop must stop at the first failure.
+	FailFast bool
+
+	// OnError is called for every failure, unless FailFast is true; in that
+	// case, the loop stops at the first failure without calling it. If nil,
+	// the loop always continues (unless FailFast is true).
+	OnError ErrorHandlerFunc[T]
+
+	// MaxRounds is the maximum number of rounds. If it is less than or equal
+	// to 0, there is no limit.
+	MaxRounds int
+
+	// MaxElements is the maximum number of elements processed in total,
+	// including the initial ones. If it is less than or equal to 0, there is
+	// no limit.
+	MaxElements int
+
+	// OnRound is called at the end of every round. If nil, nothing is done.
+	OnRound RoundFunc
+
+	// newDedup creates the function that splits elements into those whose key
+	// was not seen yet and those whose key was. Nil if no key was set with SetKey.
+	newDedup func() func(elems []T) ([]T, []T)
+}
+
+// SetKey sets the key of the elements of a do-while loop. The elements whose
+// key was already processed are dropped.
+//
+// Parameters:
+//   - config: The configuration of the loop.
+//   - key: The function that returns the key of an element. If nil, no
+//     element is dropped.
+//
+// Behaviors:
+//   - If config is nil, nothing is done.
+//   - The elements dropped in a round, including the duplicates of the initial
+//     elements for the first round, are reported by one error of type
+//     *ErrAlreadySeen[T] and counted in RoundStats.Dropped.
+//   - Every run of the loop starts with no key seen.
+func SetKey[T any, K comparable](config *DoWhileConfig[T], key func(elem T) K) {
+	if config == nil {
+		return
+	} else if key == nil {
+		config.newDedup = nil
+		return
+	}
+
+	config.newDedup = func() func(elems []T) ([]T, []T) {
+		seen := make(map[K]struct{})
+
+		return func(elems []T) ([]T, []T) {
+			var kept, dropped []T
+
+			for _, elem := range elems {
+				k := key(elem)
+
+				_, ok := seen[k]
+				if ok {
+					dropped = append(dropped, elem)
+				} else {
+					seen[k] = struct{}{}
+					kept = append(kept, elem)
+				}
+			}
+
+			return kept, dropped
+		}
+	}
+}
+
+// RoundStats are the statistics of a round of a do-while loop.
+type RoundStats struct {
+	// Round is the number of the round, starting from 1.
+	Round int
+
+	// Accepted is the number of elements accepted in the round.
+	Accepted int
+
+	// Rejected is the number of elements rejected in the round.
+	Rejected int
+
+	// Produced is the number of new elements produced by the evaluation of the
+	// rejected elements.
+	Produced int
+
+	// Errors is the number of evaluations that failed in the round.
+	Errors int
+
+	// Dropped is the number of elements dropped because their key was already
+	// seen; that is, the produced elements and, for the first round, the
+	// duplicates of the initial elements. Always 0 if no key was set with SetKey.
+	Dropped int
+}
+
+// RoundFunc is a function that is called after each round of a do-while loop.
+//
+// Parameters:
+//   - stats: The statistics of the round.
+//
+// Returns:
+//   - error: A non-nil error to abort the loop. The error is reported as is.
+type RoundFunc func(stats RoundStats) error
+
+// DoWhileE performs a do-while loop on a slice of elements while keeping track
+// of the failures.
+//
+// Parameters:
+//   - todo: The elements to perform the do-while loop on.
+//   - accept: The predicate filter to accept elements.
+//   - f: The evaluation function to perform on the elements.
+//   - config: The configuration of the loop. If nil, the default configuration is used.
+//
+// Returns:
+//   - []T: The elements that were accepted.
+//   - error: The join of the errors of type *ErrElement[T], one for each element
+//     whose evaluation failed, and of the errors of the guards that fired. Nil if
+//     no evaluation failed and no guard fired.
+//
+// Errors:
+//   - *ErrElement[T]: For each element whose evaluation failed.
+//   - *ErrAlreadySeen[T]: For each round that dropped elements because their key
+//     was already seen (see SetKey).
+//   - *ErrMaxRounds: If the loop was stopped by the maximum number of rounds.
+//   - *ErrMaxElements: If the loop was stopped by the maximum number of elements.
+//   - any error returned by OnRound.
+//
+// Behaviors:
+//   - If todo is empty, the function returns nil.
+//   - If accept is nil, the function returns nil.
+//   - If f is nil, the function returns the application of accept on todo.
+//   - FailFast and OnError are checked right after each failed evaluation; no
+//     other element is evaluated once they stop the loop.
+//   - If the loop is stopped early, the elements accepted so far are returned.
+//   - todo is never modified.
+func DoWhileE[T any](todo []T, accept slext.PredicateFilter[T], f uc.EvalManyFunc[T, T], config *DoWhileConfig[T]) ([]T, error) {
+	return doWhile(todo, accept, f, config, 1)
+}
+
+// DoWhileParallel is like DoWhileE but evaluates the elements of each round
+// concurrently.
+//
+// Parameters:
+//   - todo: The elements to perform the do-while loop on.
+//   - accept: The predicate filter to accept elements.
+//   - f: The evaluation function to perform on the elements. It must be safe
+//     for concurrent use.
+//   - workers: The maximum number of concurrent evaluations per round. If it is
+//     less than or equal to 0, runtime.GOMAXPROCS(0) is used.
+//   - config: The configuration of the loop. If nil, the default configuration is used.
+//
+// Returns:
+//   - []T: The elements that were accepted, in the same order as DoWhileE.
+//   - error: The same errors as DoWhileE.
+//
+// Behaviors:
+//   - If more than one worker is used, all the elements of a round are evaluated
+//     before its failures are handled; thus, FailFast and OnError only stop the
+//     loop at the end of a round. With one worker (including when
+//     runtime.GOMAXPROCS(0) is 1), the elements are evaluated one at a time
+//     and the loop stops right after the failure, as with DoWhileE.
+//   - OnError is never called concurrently.
+func DoWhileParallel[T any](todo []T, accept slext.PredicateFilter[T], f uc.EvalManyFunc[T, T], workers int, config *DoWhileConfig[T]) ([]T, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return doWhile(todo, accept, f, config, workers)
+}
+
+// expand evaluates the rejected elements of a round concurrently.
+//
+// Parameters:
+//   - elems: The elements to evaluate.
+//   - f: The evaluation function.
+//   - workers: The maximum number of concurrent evaluations.
+//
+// Returns:
+//   - []T: The produced elements, in order of the elements that produced them.
+//   - []*ErrElement[T]: The failures, in order of the elements.
+//
+// Behaviors:
+//   - Every element is evaluated, even if some evaluations fail.
+func expand[T any](elems []T, f uc.EvalManyFunc[T, T], workers int) ([]T, []*ErrElement[T]) {
+	results := make([][]T, len(elems))
+	reasons := make([]error, len(elems))
+
+	if workers <= 1 || len(elems) <= 1 {
+		for i, elem := range elems {
+			results[i], reasons[i] = f(elem)
+		}
+	} else {
+		workers = min(workers, len(elems))
+
+		indices := make(chan int)
+
+		var wg sync.WaitGroup
+
+		wg.Add(workers)
+
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+
+				for i := range indices {
+					results[i], reasons[i] = f(elems[i])
+				}
+			}()
+		}
+
+		for i := range elems {
+			indices <- i
+		}
+
+		close(indices)
+		wg.Wait()
+	}
+
+	var next []T
+	var failures []*ErrElement[T]
+
+	for i, elem := range elems {
+		if reasons[i] != nil {
+			failures = append(failures, NewErrElement(elem, reasons[i]))
+		} else {
+			next = append(next, results[i]...)
+		}
+	}
+
+	return next, failures
+}
+
+// DoWhileSeq is like DoWhileE but yields the accepted elements as soon as
+// they are accepted.
+//
+// Parameters:
+//   - todo: The elements to perform the do-while loop on.
+//   - accept: The predicate filter to accept elements.
+//   - f: The evaluation function to perform on the elements.
+//   - config: The configuration of the loop. If nil, the default configuration is used.
+//
+// Returns:
+//   - iter.Seq2[T, error]: The sequence of accepted elements. Each error of
+//     DoWhileE is yielded, as soon as it occurs, with the zero value of T.
+//
+// Behaviors:
+//   - The loop is performed lazily and stops as soon as the consumer stops.
+//   - The sequence can be iterated more than once; each iteration restarts the loop.
+//   - todo is never modified.
+func DoWhileSeq[T any](todo []T, accept slext.PredicateFilter[T], f uc.EvalManyFunc[T, T], config *DoWhileConfig[T]) iter.Seq2[T, error] {
+	return doWhileSeq(todo, accept, f, config, 1)
+}
+
+// doWhile is the implementation of DoWhileE and DoWhileParallel.
+//
+// Parameters:
+//   - todo: The elements to perform the do-while loop on.
+//   - accept: The predicate filter to accept elements.
+//   - f: The evaluation function to perform on the elements.
+//   - config: The configuration of the loop.
+//   - workers: The maximum number of concurrent evaluations per round.
+//
+// Returns:
+//   - []T: The elements that were accepted.
+//   - error: The joined errors.
+func doWhile[T any](todo []T, accept slext.PredicateFilter[T], f uc.EvalManyFunc[T, T], config *DoWhileConfig[T], workers int) ([]T, error) {
+	var done []T
+	var errs []error
+
+	for elem, err := range doWhileSeq(todo, accept, f, config, workers) {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			done = append(done, elem)
+		}
+	}
+
+	return done, errors.Join(errs...)
+}
+
+// doWhileSeq is the implementation of DoWhileSeq.
+//
+// Parameters:
+//   - todo: The elements to perform the do-while loop on.
+//   - accept: The predicate filter to accept elements.
+//   - f: The evaluation function to perform on the elements.
+//   - config: The configuration of the loop. If nil, the default configuration is used.
+//   - workers: The maximum number of concurrent evaluations per round.
+//
+// Returns:
+//   - iter.Seq2[T, error]: The sequence of accepted elements and errors.
+func doWhileSeq[T any](todo []T, accept slext.PredicateFilter[T], f uc.EvalManyFunc[T, T], config *DoWhileConfig[T], workers int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if len(todo) == 0 || accept == nil {
+			return
+		} else if f == nil {
+			done, _ := slext.SFSeparate(todo, accept)
+
+			for _, elem := range done {
+				if !yield(elem, nil) {
+					return
+				}
+			}
+
+			return
+		}
+
+		if config == nil {
+			config = &DoWhileConfig[T]{}
+		}
+
+		var dedup func(elems []T) ([]T, []T)
+
+		// initial is the number of duplicates of the initial elements, counted
+		// in the statistics of the first round.
+		var initial int
+
+		todo := todo
+
+		if config.newDedup != nil {
+			var dropped []T
+
+			dedup = config.newDedup()
+			todo, dropped = dedup(todo)
+			initial = len(dropped)
+
+			if initial > 0 && !yield(*new(T), NewErrAlreadySeen(0, dropped)) {
+				return
+			}
+		}
+
+		var rounds, total int
+
+		for {
+			if len(todo) == 0 {
+				return
+			}
+
+			if config.MaxRounds > 0 && rounds >= config.MaxRounds {
+				yield(*new(T), NewErrMaxRounds(config.MaxRounds))
+				return
+			}
+
+			total += len(todo)
+
+			if config.MaxElements > 0 && total > config.MaxElements {
+				yield(*new(T), NewErrMaxElements(config.MaxElements))
+				return
+			}
+
+			rounds++
+
+			s1, s2 := slext.SFSeparate(todo, accept)
+
+			for _, elem := range s1 {
+				if !yield(elem, nil) {
+					return
+				}
+			}
+
+			// handle reports a failure and checks whether the loop must go on.
+			handle := func(failure *ErrElement[T]) bool {
+				if !yield(*new(T), failure) || config.FailFast {
+					return false
+				}
+
+				return config.OnError == nil || config.OnError(failure.Elem, failure.Reason)
+			}
+
+			var next []T
+			var failures []*ErrElement[T]
+
+			if workers <= 1 {
+				for _, elem := range s2 {
+					others, err := f(elem)
+					if err == nil {
+						next = append(next, others...)
+						continue
+					}
+
+					failure := NewErrElement(elem, err)
+					failures = append(failures, failure)
+
+					if !handle(failure) {
+						return
+					}
+				}
+			} else {
+				next, failures = expand(s2, f, workers)
+
+				for _, failure := range failures {
+					if !handle(failure) {
+						return
+					}
+				}
+			}
+
+			produced := len(next)
+
+			var dropped []T
+
+			if dedup != nil {
+				next, dropped = dedup(next)
+
+				if len(dropped) > 0 && !yield(*new(T), NewErrAlreadySeen(rounds, dropped)) {
+					return
+				}
+			}
+
+			if config.OnRound != nil {
+				stats := RoundStats{
+					Round:    rounds,
+					Accepted: len(s1),
+					Rejected: len(s2),
+					Produced: produced,
+					Errors:   len(failures),
+					Dropped:  initial + len(dropped),
+				}
+
+				err := config.OnRound(stats)
+				if err != nil {
+					yield(*new(T), err)
+					return
+				}
+			}
+
+			initial = 0
+			todo = next
+		}
+	}
+}
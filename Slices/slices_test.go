@@ -0,0 +1,141 @@
+package Slices
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// TestDoWhileFailFastCalls checks how many elements are evaluated before a
+// FailFast loop stops.
+func TestDoWhileFailFastCalls(t *testing.T) {
+	var calls atomic.Int32
+
+	f := func(elem int) ([]int, error) {
+		calls.Add(1)
+
+		if elem == 1 {
+			return nil, errors.New("failure")
+		}
+
+		return nil, nil
+	}
+
+	accept := func(elem int) bool {
+		return false
+	}
+
+	todo := []int{0, 1, 2, 3, 4}
+	config := &DoWhileConfig[int]{FailFast: true}
+
+	_, err := DoWhileE(todo, accept, f, config)
+	if err == nil {
+		t.Fatalf("DoWhileE: expected an error, got nil")
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("DoWhileE: expected 2 calls, got %d", got)
+	}
+
+	calls.Store(0)
+
+	_, err = DoWhileParallel(todo, accept, f, 2, config)
+	if err == nil {
+		t.Fatalf("DoWhileParallel: expected an error, got nil")
+	}
+
+	if got := calls.Load(); got != int32(len(todo)) {
+		t.Errorf("DoWhileParallel: expected %d calls, got %d", len(todo), got)
+	}
+}
+
+// TestDoWhileOnErrorCalls checks that OnError stops a sequential loop right
+// after the failure it rejects.
+func TestDoWhileOnErrorCalls(t *testing.T) {
+	var calls int
+
+	f := func(elem int) ([]int, error) {
+		calls++
+
+		return nil, errors.New("failure")
+	}
+
+	accept := func(elem int) bool {
+		return false
+	}
+
+	var handled int
+
+	config := &DoWhileConfig[int]{
+		OnError: func(elem int, reason error) bool {
+			handled++
+
+			return elem != 2
+		},
+	}
+
+	_, err := DoWhileE([]int{0, 1, 2, 3, 4}, accept, f, config)
+	if err == nil {
+		t.Fatalf("expected an error, got nil")
+	}
+
+	if calls != 3 || handled != 3 {
+		t.Errorf("expected 3 calls and 3 handled failures, got %d and %d", calls, handled)
+	}
+}
+
+// TestDoWhileKey checks that the elements whose key was already seen are
+// dropped, counted and reported once per round.
+func TestDoWhileKey(t *testing.T) {
+	edges := map[int][]int{
+		0: {1, 2},
+		1: {3},
+		2: {3},
+	}
+
+	f := func(elem int) ([]int, error) {
+		return edges[elem], nil
+	}
+
+	accept := func(elem int) bool {
+		return elem == 3
+	}
+
+	var dropped []int
+
+	config := &DoWhileConfig[int]{
+		OnRound: func(stats RoundStats) error {
+			dropped = append(dropped, stats.Dropped)
+			return nil
+		},
+	}
+
+	SetKey(config, func(elem int) int {
+		return elem
+	})
+
+	done, err := DoWhileE([]int{0, 0}, accept, f, config)
+
+	if len(done) != 1 || done[0] != 3 {
+		t.Errorf("expected [3], got %v", done)
+	}
+
+	if !slices.Equal(dropped, []int{1, 1, 0}) {
+		t.Errorf("expected drops [1 1 0], got %v", dropped)
+	}
+
+	var seen *ErrAlreadySeen[int]
+
+	if !errors.As(err, &seen) {
+		t.Fatalf("expected an *ErrAlreadySeen, got %v", err)
+	}
+
+	if seen.Round != 0 || !slices.Equal(seen.Elems, []int{0}) {
+		t.Errorf("expected the initial duplicate first, got round %d and %v", seen.Round, seen.Elems)
+	}
+
+	if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != 2 {
+		t.Errorf("expected 2 errors, got %d", got)
+	}
+}
@@ -0,0 +1,214 @@
+package Slices
+
+import (
+	"errors"
+	"strconv"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// SoftErrorPolicy is the policy applied when the Core method of a leaf
+// evaluater returns a soft error.
+type SoftErrorPolicy int
+
+const (
+	// SoftErrorPass passes the soft error to the Next method as is.
+	SoftErrorPass SoftErrorPolicy = iota
+
+	// SoftErrorDrop drops every branch.
+	SoftErrorDrop
+
+	// SoftErrorRecover replaces every branch with the branches returned by
+	// the recovery function.
+	SoftErrorRecover
+
+	// SoftErrorSkip skips the element and keeps the branches unchanged.
+	SoftErrorSkip
+)
+
+// String implements the fmt.Stringer interface.
+func (p SoftErrorPolicy) String() string {
+	names := [...]string{
+		"pass",
+		"drop",
+		"recover",
+		"skip",
+	}
+
+	if p < 0 || int(p) >= len(names) {
+		return "SoftErrorPolicy(" + strconv.Itoa(int(p)) + ")"
+	}
+
+	return names[p]
+}
+
+// RecoverFunc is a function that forks the error-recovery branches of a branch.
+//
+// Parameters:
+//   - index: The index of the loop element.
+//   - reason: The soft error.
+//   - branch: The current branch.
+//
+// Returns:
+//   - []T: The recovery branches.
+//   - error: An error if the recovery fails (reserved for panic-level of critical errors).
+type RecoverFunc[T any] func(index int, reason error, branch T) ([]T, error)
+
+// SoftErrorConfig is the configuration of the soft error handling.
+type SoftErrorConfig[T any] struct {
+	// Policy is the policy to apply on soft errors.
+	Policy SoftErrorPolicy
+
+	// RecoverFn is the recovery function. Required if Policy is SoftErrorRecover.
+	RecoverFn RecoverFunc[T]
+}
+
+// Diagnostic is the record of a branch dropped because of a soft error.
+type Diagnostic[T any] struct {
+	// Index is the index of the element that caused the soft error.
+	Index int
+
+	// Branch is the dropped branch.
+	Branch T
+
+	// Reason is the soft error.
+	Reason error
+}
+
+// NewDiagnostic creates a new Diagnostic.
+//
+// Parameters:
+//   - index: The index of the element that caused the soft error.
+//   - branch: The dropped branch.
+//   - reason: The soft error.
+//
+// Returns:
+//   - *Diagnostic[T]: The new Diagnostic.
+func NewDiagnostic[T any](index int, branch T, reason error) *Diagnostic[T] {
+	return &Diagnostic[T]{
+		Index:  index,
+		Branch: branch,
+		Reason: reason,
+	}
+}
+
+// EvaluateWithPolicy performs a leaf evaluation with a loop where soft errors
+// are handled according to a policy.
+//
+// Parameters:
+//   - elem: The evaluable element.
+//   - args: The arguments.
+//   - config: The configuration of the soft error handling. If nil, SoftErrorPass
+//     is used.
+//
+// Returns:
+//   - []T: The branches.
+//   - []*Diagnostic[T]: The diagnostics of the dropped branches.
+//   - error: An error if the evaluation fails (reserved for panic-level of critical errors).
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If the policy is unknown, or if it is
+//     SoftErrorRecover and RecoverFn is nil.
+//   - any error returned by the evaluator or the recovery function.
+//
+// Behaviors:
+//   - A soft error is the non-nil second value of the pair returned by Core.
+//   - With SoftErrorRecover, a branch for which RecoverFn returns no branch is dropped.
+//   - If elem is nil, the function returns nil.
+func EvaluateWithPolicy[A, T, E, R any](elem LeafEvaluable[A, T, E, R], args []A, config *SoftErrorConfig[T]) ([]T, []*Diagnostic[T], error) {
+	if config == nil {
+		config = &SoftErrorConfig[T]{
+			Policy: SoftErrorPass,
+		}
+	} else if config.Policy < SoftErrorPass || config.Policy > SoftErrorSkip {
+		return nil, nil, uc.NewErrInvalidParameter("config", errors.New("unknown policy "+config.Policy.String()))
+	} else if config.Policy == SoftErrorRecover && config.RecoverFn == nil {
+		return nil, nil, uc.NewErrInvalidParameter("config", errors.New("RecoverFn must be set for the recover policy"))
+	}
+
+	if elem == nil {
+		return nil, nil, nil
+	}
+
+	ev := elem.Evaluator()
+	if ev == nil {
+		return nil, nil, nil
+	}
+
+	branches, err := initBranches(ev, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var diagnostics []*Diagnostic[T]
+
+	index := 0
+	iter := ev.Iterator()
+	defer stopIter(iter)
+
+	for len(branches) > 0 {
+		value, err := iter.Consume()
+		if err != nil {
+			break
+		}
+
+		pair, err := ev.Core(index, value)
+		if err != nil {
+			return branches, diagnostics, err
+		}
+
+		if pair == nil || pair.Second == nil || config.Policy == SoftErrorPass {
+			var newBranches []T
+
+			for _, branch := range branches {
+				tmp, err := ev.Next(pair, branch)
+				if err != nil {
+					return branches, diagnostics, err
+				}
+
+				if len(tmp) > 0 {
+					newBranches = append(newBranches, tmp...)
+				}
+			}
+
+			branches = newBranches
+			index++
+
+			continue
+		}
+
+		reason := pair.Second
+
+		switch config.Policy {
+		case SoftErrorDrop:
+			for _, branch := range branches {
+				diagnostics = append(diagnostics, NewDiagnostic(index, branch, reason))
+			}
+
+			branches = nil
+		case SoftErrorRecover:
+			var newBranches []T
+
+			for _, branch := range branches {
+				tmp, err := config.RecoverFn(index, reason, branch)
+				if err != nil {
+					return branches, diagnostics, err
+				}
+
+				if len(tmp) == 0 {
+					diagnostics = append(diagnostics, NewDiagnostic(index, branch, reason))
+				} else {
+					newBranches = append(newBranches, tmp...)
+				}
+			}
+
+			branches = newBranches
+		case SoftErrorSkip:
+			// Do nothing.
+		}
+
+		index++
+	}
+
+	return branches, diagnostics, nil
+}
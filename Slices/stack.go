@@ -1,7 +1,15 @@
 package Slices
 
 import (
+	"container/heap"
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+	"strconv"
+
 	uc "github.com/PlayerR9/lib_units/common"
+	llq "github.com/PlayerR9/listlike/queue"
 	lls "github.com/PlayerR9/listlike/stack"
 )
 
@@ -55,8 +63,115 @@ type NextsFunc[T any] func(wasDone bool, last T) ([]T, error)
 //   - error: An error if the next elements could not be filtered.
 type FilterNextsFunc[T any] func(wasDone bool, nexts []T) ([]T, error)
 
+// PathFilterFunc is a function that filters the next elements knowing the
+// current path.
+//
+// Parameters:
+//   - wasDone: If the last element was done.
+//   - path: The current path. It must not be modified.
+//   - depth: The length of the current path.
+//   - nexts: The next elements.
+//
+// Returns:
+//   - []T: The filtered next elements.
+//   - error: An error if the next elements could not be filtered.
+type PathFilterFunc[T any, E Laster[T]] func(wasDone bool, path E, depth int, nexts []T) ([]T, error)
+
+// Walker is an interface for a Laster whose elements can be walked from the
+// last to the first.
+type Walker[T any] interface {
+	Laster[T]
+
+	// Backward returns a sequence over the elements, from the last to the first.
+	//
+	// Returns:
+	//   - iter.Seq[T]: The sequence of elements.
+	Backward() iter.Seq[T]
+}
+
+// SimplePathFilter is a path filter that forbids revisiting an element of the
+// path.
+//
+// Parameters:
+//   - wasDone: If the last element was done.
+//   - path: The current path.
+//   - depth: The length of the current path.
+//   - nexts: The next elements.
+//
+// Returns:
+//   - []T: The next elements that are not in the path. Nil if wasDone is true.
+//   - error: Always nil.
+//
+// Behaviors:
+//   - The path is walked once into a set; thus, the filter runs in
+//     O(depth + len(nexts)) time.
+func SimplePathFilter[T comparable, E Walker[T]](wasDone bool, path E, depth int, nexts []T) ([]T, error) {
+	if wasDone {
+		return nil, nil
+	}
+
+	visited := make(map[T]struct{}, depth)
+
+	for elem := range path.Backward() {
+		visited[elem] = struct{}{}
+	}
+
+	var filtered []T
+
+	for _, next := range nexts {
+		_, ok := visited[next]
+		if !ok {
+			filtered = append(filtered, next)
+		}
+	}
+
+	return filtered, nil
+}
+
+// LimitKind is the kind of a limit of a StackEvaluator.
+type LimitKind int
+
+const (
+	// LimitDepth is the limit on the length of a path.
+	LimitDepth LimitKind = iota
+
+	// LimitSolutions is the limit on the number of solutions.
+	LimitSolutions
+
+	// LimitExpanded is the limit on the number of expanded paths.
+	LimitExpanded
+)
+
+// String implements the fmt.Stringer interface.
+func (k LimitKind) String() string {
+	names := [...]string{
+		"maximum depth",
+		"maximum number of solutions",
+		"maximum number of expanded paths",
+	}
+
+	if k < 0 || int(k) >= len(names) {
+		return "LimitKind(" + strconv.Itoa(int(k)) + ")"
+	}
+
+	return names[k]
+}
+
+// FactoryFunc is a function that creates a new Laster.
+//
+// Parameters:
+//   - elems: The elements to add to the Laster.
+//
+// Returns:
+//   - E: The new Laster.
+//   - error: An error if the Laster could not be created.
+type FactoryFunc[T any, E Laster[T]] func(elems []T) (E, error)
+
 // StackEvaluator evaluates a stack of elements.
 type StackEvaluator[T any, E Laster[T]] struct {
+	// factory is the function that creates the first path.
+	factory FactoryFunc[T, E]
+
 	// eval is the evaluation function.
 	eval uc.EvalOneFunc[T, bool]
 
@@ -65,6 +180,20 @@ type StackEvaluator[T any, E Laster[T]] struct {
 
 	// filter is the function to filter the next elements.
 	filter FilterNextsFunc[T]
+
+	// pathFilter is the path-aware function to filter the next elements. If
+	// not nil, it is used instead of filter.
+	pathFilter PathFilterFunc[T, E]
+
+	// limits are the limits of the evaluation.
+	limits StackLimits
+
+	// mode is the search mode of the evaluation.
+	mode SearchMode
+
+	// cost is the function that returns the cost of an edge. If nil, every
+	// edge costs 1.
+	cost CostFunc[T]
 }
 
 // NewStackEvaluator creates a new StackEvaluator.
@@ -82,16 +211,42 @@ type StackEvaluator[T any, E Laster[T]] struct {
 //   - By default, the filter function will return the nexts if the
 //     last element was not done. If the last element was done, it
 //     will return nil.
+//   - The first path is created by calling From on the zero value of E. Use
+//     NewStackEvaluatorWithFactory if the zero value of E cannot be used.
 func NewStackEvaluator[T any, E Laster[T]](eval uc.EvalOneFunc[T, bool], nexts NextsFunc[T]) (*StackEvaluator[T, E], error) {
+	return NewStackEvaluatorWithFactory(eval, nexts, zeroFactory[T, E])
+}
+
+// NewStackEvaluatorWithFactory creates a new StackEvaluator that creates the
+// first path with the given factory.
+//
+// Parameters:
+//   - eval: The evaluation function.
+//   - nexts: The function to get the next elements.
+//   - factory: The function that creates the first path.
+//
+// Returns:
+//   - *StackEvaluator[T, E]: The new StackEvaluator.
+//   - error: An error of type *errors.ErrInvalidParameter if
+//     the eval, nexts or factory functions are nil.
+//
+// Behaviors:
+//   - By default, the filter function will return the nexts if the
+//     last element was not done. If the last element was done, it
+//     will return nil.
+func NewStackEvaluatorWithFactory[T any, E Laster[T]](eval uc.EvalOneFunc[T, bool], nexts NextsFunc[T], factory FactoryFunc[T, E]) (*StackEvaluator[T, E], error) {
 	if eval == nil {
 		return nil, uc.NewErrNilParameter("eval")
 	} else if nexts == nil {
 		return nil, uc.NewErrNilParameter("nexts")
+	} else if factory == nil {
+		return nil, uc.NewErrNilParameter("factory")
 	}
 
 	return &StackEvaluator[T, E]{
-		eval:  eval,
-		nexts: nexts,
+		factory: factory,
+		eval:    eval,
+		nexts:   nexts,
 		filter: func(wasDone bool, nexts []T) ([]T, error) {
 			if wasDone {
 				return nil, nil
@@ -102,6 +257,52 @@ func NewStackEvaluator[T any, E Laster[T]](eval uc.EvalOneFunc[T, bool], nexts N
 	}, nil
 }
 
+// zeroFactory creates a new Laster by calling From on the zero value of E.
+//
+// Parameters:
+//   - elems: The elements to add to the Laster.
+//
+// Returns:
+//   - E: The new Laster.
+//   - error: An error if the Laster could not be created.
+//
+// Errors:
+//   - *ErrUnexpectedType: If From does not return a value of type E.
+//   - any error returned by From.
+func zeroFactory[T any, E Laster[T]](elems []T) (E, error) {
+	first, err := (*new(E)).From(elems)
+	if err != nil {
+		return *new(E), err
+	}
+
+	e, ok := first.(E)
+	if !ok {
+		return *new(E), NewErrUnexpectedType(first, reflect.TypeFor[E]().String())
+	}
+
+	return e, nil
+}
+
+// copyOf copies a Laster.
+//
+// Parameters:
+//   - elem: The Laster to copy.
+//
+// Returns:
+//   - E: The copy.
+//   - error: An error of type *ErrUnexpectedType if Copy does not return a
+//     value of type E.
+func copyOf[T any, E Laster[T]](elem E) (E, error) {
+	c := elem.Copy()
+
+	e, ok := c.(E)
+	if !ok {
+		return *new(E), NewErrUnexpectedType(c, reflect.TypeFor[E]().String())
+	}
+
+	return e, nil
+}
+
 // SetFilter sets the filter function.
 //
 // Parameters:
@@ -117,6 +318,240 @@ func (se *StackEvaluator[T, E]) SetFilter(filter FilterNextsFunc[T]) {
 	se.filter = filter
 }
 
+// SetPathFilter sets the path-aware filter function.
+//
+// Parameters:
+//   - filter: The path-aware filter function.
+//
+// Behaviors:
+//   - If the path-aware filter function is not nil, it is used instead of
+//     the filter function set with SetFilter.
+//   - If the path-aware filter function is nil, the filter function set with
+//     SetFilter will be used.
+func (se *StackEvaluator[T, E]) SetPathFilter(filter PathFilterFunc[T, E]) {
+	se.pathFilter = filter
+}
+
+// filterNexts filters the next elements of a path.
+//
+// Parameters:
+//   - wasDone: If the last element was done.
+//   - top: The current path.
+//   - nexts: The next elements.
+//
+// Returns:
+//   - []T: The filtered next elements.
+//   - error: An error if the next elements could not be filtered.
+func (se *StackEvaluator[T, E]) filterNexts(wasDone bool, top stackItem[E], nexts []T) ([]T, error) {
+	if se.pathFilter != nil {
+		return se.pathFilter(wasDone, top.path, top.depth, nexts)
+	}
+
+	return se.filter(wasDone, nexts)
+}
+
+// StackLimits are the limits of a StackEvaluator. A limit less than or equal
+// to 0 means no limit.
+type StackLimits struct {
+	// MaxDepth is the maximum length of a path. Paths of this length are not
+	// extended.
+	MaxDepth int
+
+	// MaxSolutions is the maximum number of solutions.
+	MaxSolutions int
+
+	// MaxExpanded is the maximum number of paths expanded.
+	MaxExpanded int
+}
+
+// SetLimits sets the limits of the evaluation.
+//
+// Parameters:
+//   - limits: The limits.
+func (se *StackEvaluator[T, E]) SetLimits(limits StackLimits) {
+	se.limits = limits
+}
+
+// SearchMode is the order in which a StackEvaluator explores the paths.
+type SearchMode int
+
+const (
+	// DepthFirst explores the paths depth-first. This is the default mode.
+	DepthFirst SearchMode = iota
+
+	// BreadthFirst explores the paths breadth-first. The solutions are
+	// ordered by length.
+	BreadthFirst
+
+	// IterativeDeepening explores the paths depth-first with an increasing
+	// maximum length. The solutions are ordered by length.
+	IterativeDeepening
+
+	// BestFirst explores the paths by increasing total cost. The solutions
+	// are ordered by total cost. The costs must not be negative.
+	BestFirst
+)
+
+// String implements the fmt.Stringer interface.
+func (m SearchMode) String() string {
+	names := [...]string{
+		"depth-first",
+		"breadth-first",
+		"iterative deepening",
+		"best-first",
+	}
+
+	if m < 0 || int(m) >= len(names) {
+		return "SearchMode(" + strconv.Itoa(int(m)) + ")"
+	}
+
+	return names[m]
+}
+
+// SetMode sets the search mode of the evaluation.
+//
+// Parameters:
+//   - mode: The search mode.
+//
+// Behaviors:
+//   - If the mode is unknown, the current mode is kept.
+//   - With IterativeDeepening, the paths are expanded once per pass; thus, the
+//     MaxExpanded limit counts the re-expansions too.
+func (se *StackEvaluator[T, E]) SetMode(mode SearchMode) {
+	if mode < DepthFirst || mode > BestFirst {
+		return
+	}
+
+	se.mode = mode
+}
+
+// stackItem is a path in the frontier of a StackEvaluator.
+type stackItem[E any] struct {
+	// path is the path.
+	path E
+
+	// depth is the length of the path.
+	depth int
+
+	// cost is the total cost of the path.
+	cost float64
+}
+
+// frontier is the collection of paths that are yet to be expanded.
+type frontier[E any] interface {
+	// push adds a path to the frontier.
+	//
+	// Parameters:
+	//   - item: The path to add.
+	push(item stackItem[E])
+
+	// pop removes the next path to expand from the frontier.
+	//
+	// Returns:
+	//   - stackItem[E]: The next path.
+	//   - bool: False if the frontier is empty.
+	pop() (stackItem[E], bool)
+}
+
+// stackFrontier is a LIFO frontier.
+type stackFrontier[E any] struct {
+	// stack is the underlying stack.
+	stack *lls.LinkedStack[stackItem[E]]
+}
+
+// push implements the frontier interface.
+func (sf *stackFrontier[E]) push(item stackItem[E]) {
+	sf.stack.Push(item)
+}
+
+// pop implements the frontier interface.
+func (sf *stackFrontier[E]) pop() (stackItem[E], bool) {
+	return sf.stack.Pop()
+}
+
+// heapFrontier is a frontier ordered by increasing cost. Paths of equal cost
+// are popped in insertion order.
+type heapFrontier[E any] struct {
+	// items are the paths of the frontier.
+	items []stackItem[E]
+
+	// orders are the insertion orders of the paths.
+	orders []int
+
+	// counter is the number of paths pushed so far.
+	counter int
+}
+
+// Len implements the heap.Interface interface.
+func (hf *heapFrontier[E]) Len() int {
+	return len(hf.items)
+}
+
+// Less implements the heap.Interface interface.
+func (hf *heapFrontier[E]) Less(i, j int) bool {
+	if hf.items[i].cost != hf.items[j].cost {
+		return hf.items[i].cost < hf.items[j].cost
+	}
+
+	return hf.orders[i] < hf.orders[j]
+}
+
+// Swap implements the heap.Interface interface.
+func (hf *heapFrontier[E]) Swap(i, j int) {
+	hf.items[i], hf.items[j] = hf.items[j], hf.items[i]
+	hf.orders[i], hf.orders[j] = hf.orders[j], hf.orders[i]
+}
+
+// Push implements the heap.Interface interface.
+func (hf *heapFrontier[E]) Push(x any) {
+	hf.items = append(hf.items, x.(stackItem[E]))
+	hf.orders = append(hf.orders, hf.counter)
+	hf.counter++
+}
+
+// Pop implements the heap.Interface interface.
+func (hf *heapFrontier[E]) Pop() any {
+	n := len(hf.items) - 1
+
+	item := hf.items[n]
+
+	hf.items[n] = stackItem[E]{}
+	hf.items = hf.items[:n]
+	hf.orders = hf.orders[:n]
+
+	return item
+}
+
+// push implements the frontier interface.
+func (hf *heapFrontier[E]) push(item stackItem[E]) {
+	heap.Push(hf, item)
+}
+
+// pop implements the frontier interface.
+func (hf *heapFrontier[E]) pop() (stackItem[E], bool) {
+	if len(hf.items) == 0 {
+		return stackItem[E]{}, false
+	}
+
+	return heap.Pop(hf).(stackItem[E]), true
+}
+
+// queueFrontier is a FIFO frontier.
+type queueFrontier[E any] struct {
+	// queue is the underlying queue.
+	queue *llq.LinkedQueue[stackItem[E]]
+}
+
+// push implements the frontier interface.
+func (qf *queueFrontier[E]) push(item stackItem[E]) {
+	qf.queue.Enqueue(item)
+}
+
+// pop implements the frontier interface.
+func (qf *queueFrontier[E]) pop() (stackItem[E], bool) {
+	return qf.queue.Dequeue()
+}
+
 // Evaluate evaluates the stack of elements from the given element.
 //
 // Parameters:
@@ -125,60 +560,447 @@ func (se *StackEvaluator[T, E]) SetFilter(filter FilterNextsFunc[T]) {
 // Returns:
 //   - []E: The evaluated elements.
 //   - error: An error if the elements could not be evaluated.
+//
+// Behaviors:
+//   - Same as EvaluateContext with context.Background().
 func (se *StackEvaluator[T, E]) Evaluate(elem T) ([]E, error) {
+	return se.EvaluateContext(context.Background(), elem)
+}
+
+// EvaluateContext evaluates the stack of elements from the given element
+// within the limits of the evaluator.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - elem: The element to start the evaluation.
+//
+// Returns:
+//   - []E: The evaluated elements.
+//   - error: An error if the elements could not be evaluated.
+//
+// Errors:
+//   - *ErrLimitReached: If one of the limits was reached.
+//   - the error of the context if it is done.
+//   - *ErrPath[E]: If the eval, nexts or filter functions fail on a path, or
+//     if a path could not be copied or has no last element.
+//   - any error returned by the factory function.
+//
+// Behaviors:
+//   - On error, the solutions found so far are returned too.
+//   - The MaxDepth limit does not stop the evaluation; the error is only
+//     returned at the end if at least one path was not extended because of it.
+func (se *StackEvaluator[T, E]) EvaluateContext(ctx context.Context, elem T) ([]E, error) {
 	var done []E
 
-	first, err := (*new(E)).From([]T{elem})
+	err := se.run(ctx, elem, se.mode, func(item stackItem[E]) bool {
+		done = append(done, item.path)
+		return true
+	})
+
+	return done, err
+}
+
+// Solutions returns a sequence over the solutions, yielded as soon as they
+// are found.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - elem: The element to start the evaluation.
+//
+// Returns:
+//   - iter.Seq2[E, error]: The sequence of solutions. If the evaluation fails,
+//     the error is yielded last with the zero value of E.
+//
+// Behaviors:
+//   - The evaluation stops as soon as the consumer stops.
+//   - The errors are the same as EvaluateContext.
+func (se *StackEvaluator[T, E]) Solutions(ctx context.Context, elem T) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		stopped := false
+
+		err := se.run(ctx, elem, se.mode, func(item stackItem[E]) bool {
+			if !yield(item.path, nil) {
+				stopped = true
+			}
+
+			return !stopped
+		})
+
+		if err != nil && !stopped {
+			yield(*new(E), err)
+		}
+	}
+}
+
+// First evaluates the stack of elements from the given element until the
+// first solution is found.
+//
+// Parameters:
+//   - elem: The element to start the evaluation.
+//
+// Returns:
+//   - E: The first solution.
+//   - bool: True if a solution was found, false otherwise.
+//   - error: An error if the evaluation failed before finding a solution.
+//
+// Errors:
+//   - same as EvaluateContext.
+func (se *StackEvaluator[T, E]) First(elem T) (E, bool, error) {
+	var first E
+	var found bool
+
+	err := se.run(context.Background(), elem, se.mode, func(item stackItem[E]) bool {
+		first = item.path
+		found = true
+
+		return false
+	})
+	if err != nil {
+		return *new(E), false, err
+	}
+
+	return first, found, nil
+}
+
+// searchState is the state shared by the passes of an evaluation.
+type searchState struct {
+	// solutions is the number of solutions found.
+	solutions int
+
+	// expanded is the number of expanded paths.
+	expanded int
+}
+
+// carrier is what the items of a frontier carry: either the whole path or
+// only its last element.
+type carrier[T, P any] interface {
+	// first creates the item of the first path.
+	//
+	// Parameters:
+	//   - elem: The element to start the evaluation.
+	//
+	// Returns:
+	//   - P: The item.
+	//   - error: An error if the item could not be created.
+	first(elem T) (P, error)
+
+	// last returns the last element of an item.
+	//
+	// Parameters:
+	//   - item: The item.
+	//
+	// Returns:
+	//   - T: The last element.
+	//   - bool: False if the item has no last element.
+	last(item P) (T, bool)
+
+	// filter filters the next elements of an item.
+	//
+	// Parameters:
+	//   - wasDone: If the last element was done.
+	//   - top: The item.
+	//   - nexts: The next elements.
+	//
+	// Returns:
+	//   - []T: The filtered next elements.
+	//   - error: An error if the next elements could not be filtered.
+	filter(wasDone bool, top stackItem[P], nexts []T) ([]T, error)
+
+	// extend creates the item that follows an item with a next element.
+	//
+	// Parameters:
+	//   - item: The item to extend. It is not modified.
+	//   - next: The next element.
+	//
+	// Returns:
+	//   - P: The new item.
+	//   - error: An error if the item could not be extended.
+	extend(item P, next T) (P, error)
+
+	// fail creates the error of a failed operation on an item.
+	//
+	// Parameters:
+	//   - operation: The name of the operation that failed.
+	//   - top: The item.
+	//   - reason: The reason of the failure.
+	//
+	// Returns:
+	//   - error: The error. Always of type *ErrPath[E].
+	fail(operation string, top stackItem[P], reason error) error
+}
+
+// pathCarrier is a carrier of whole paths.
+type pathCarrier[T any, E Laster[T]] struct {
+	// se is the evaluator.
+	se *StackEvaluator[T, E]
+}
+
+// first implements the carrier interface.
+func (pc pathCarrier[T, E]) first(elem T) (E, error) {
+	return pc.se.factory([]T{elem})
+}
+
+// last implements the carrier interface.
+func (pc pathCarrier[T, E]) last(item E) (T, bool) {
+	return item.GetLast()
+}
+
+// filter implements the carrier interface.
+func (pc pathCarrier[T, E]) filter(wasDone bool, top stackItem[E], nexts []T) ([]T, error) {
+	return pc.se.filterNexts(wasDone, top, nexts)
+}
+
+// extend implements the carrier interface.
+func (pc pathCarrier[T, E]) extend(item E, next T) (E, error) {
+	c, err := copyOf[T](item)
+	if err != nil {
+		return *new(E), err
+	}
+
+	c.Append(next)
+
+	return c, nil
+}
+
+// fail implements the carrier interface.
+func (pc pathCarrier[T, E]) fail(operation string, top stackItem[E], reason error) error {
+	return NewErrPath(operation, top.path, top.depth, reason)
+}
+
+// lastCarrier is a carrier of the last elements of the paths only.
+type lastCarrier[T any, E Laster[T]] struct {
+	// se is the evaluator.
+	se *StackEvaluator[T, E]
+}
+
+// first implements the carrier interface.
+func (lc lastCarrier[T, E]) first(elem T) (T, error) {
+	return elem, nil
+}
+
+// last implements the carrier interface.
+func (lc lastCarrier[T, E]) last(item T) (T, bool) {
+	return item, true
+}
+
+// filter implements the carrier interface.
+//
+// The path-aware filter is never used since there is no path.
+func (lc lastCarrier[T, E]) filter(wasDone bool, top stackItem[T], nexts []T) ([]T, error) {
+	return lc.se.filter(wasDone, nexts)
+}
+
+// extend implements the carrier interface.
+func (lc lastCarrier[T, E]) extend(item T, next T) (T, error) {
+	return next, nil
+}
+
+// fail implements the carrier interface.
+//
+// The path of the error only holds the last element since the others are not
+// kept. It is the zero value of E if the factory fails.
+func (lc lastCarrier[T, E]) fail(operation string, top stackItem[T], reason error) error {
+	path, _ := lc.se.factory([]T{top.path})
+
+	return NewErrPath(operation, path, top.depth, reason)
+}
+
+// run performs the evaluation over whole paths.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - elem: The element to start the evaluation.
+//   - mode: The search mode.
+//   - yield: The function called on every solution. If it returns false, the
+//     evaluation stops.
+//
+// Returns:
+//   - error: An error if the evaluation failed or a limit was reached.
+func (se *StackEvaluator[T, E]) run(ctx context.Context, elem T, mode SearchMode, yield func(item stackItem[E]) bool) error {
+	return run(ctx, se, pathCarrier[T, E]{se: se}, elem, mode, yield)
+}
+
+// run performs the evaluation.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - se: The evaluator.
+//   - c: The carrier of the items.
+//   - elem: The element to start the evaluation.
+//   - mode: The search mode.
+//   - yield: The function called on every solution. If it returns false, the
+//     evaluation stops.
+//
+// Returns:
+//   - error: An error if the evaluation failed or a limit was reached.
+func run[T any, E Laster[T], P any](ctx context.Context, se *StackEvaluator[T, E], c carrier[T, P], elem T, mode SearchMode, yield func(item stackItem[P]) bool) error {
+	first, err := c.first(elem)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	var state searchState
+
+	if mode != IterativeDeepening {
+		var f frontier[P]
+
+		switch mode {
+		case BreadthFirst:
+			f = &queueFrontier[P]{
+				queue: llq.NewLinkedQueue[stackItem[P]](),
+			}
+		case BestFirst:
+			f = &heapFrontier[P]{}
+		default:
+			f = &stackFrontier[P]{
+				stack: lls.NewLinkedStack[stackItem[P]](),
+			}
+		}
+
+		f.push(stackItem[P]{
+			path:  first,
+			depth: 1,
+		})
+
+		cut, stop, err := search(ctx, se, c, f, se.limits.MaxDepth, 0, &state, yield)
+		if err != nil || stop {
+			return err
+		}
+
+		if cut {
+			return NewErrLimitReached(LimitDepth, se.limits.MaxDepth)
+		}
+
+		return nil
+	}
+
+	for depth := 1; ; depth++ {
+		f := &stackFrontier[P]{
+			stack: lls.NewLinkedStack[stackItem[P]](),
+		}
+
+		f.push(stackItem[P]{
+			path:  first,
+			depth: 1,
+		})
+
+		cut, stop, err := search(ctx, se, c, f, depth, depth, &state, yield)
+		if err != nil || stop || !cut {
+			return err
+		}
+
+		if se.limits.MaxDepth > 0 && depth >= se.limits.MaxDepth {
+			return NewErrLimitReached(LimitDepth, se.limits.MaxDepth)
+		}
 	}
+}
 
-	S := lls.NewLinkedStack[E]()
+// search performs one pass of the evaluation.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - se: The evaluator.
+//   - c: The carrier of the items.
+//   - f: The frontier, initialized with the first path.
+//   - maxDepth: The length after which paths are not extended. If it is less
+//     than or equal to 0, there is no limit.
+//   - onlyDepth: If greater than 0, only the solutions of this length are yielded.
+//   - state: The state shared by the passes.
+//   - yield: The function called on every solution.
+//
+// Returns:
+//   - bool: True if at least one path was not extended because of maxDepth.
+//   - bool: True if yield asked to stop.
+//   - error: An error if the evaluation failed or a limit was reached.
+func search[T any, E Laster[T], P any](ctx context.Context, se *StackEvaluator[T, E], c carrier[T, P], f frontier[P], maxDepth, onlyDepth int, state *searchState, yield func(item stackItem[P]) bool) (bool, bool, error) {
+	var cut bool
 
-	S.Push(first.(E))
+	_, bestFirst := f.(*heapFrontier[P])
 
 	for {
-		top, ok := S.Pop()
+		top, ok := f.pop()
 		if !ok {
 			break
 		}
 
-		last, ok := top.GetLast()
+		err := ctx.Err()
+		if err != nil {
+			return cut, false, err
+		}
+
+		if se.limits.MaxExpanded > 0 && state.expanded >= se.limits.MaxExpanded {
+			return cut, false, NewErrLimitReached(LimitExpanded, se.limits.MaxExpanded)
+		}
+
+		state.expanded++
+
+		last, ok := c.last(top.path)
 		if !ok {
-			return nil, NewErrLastNotFound()
+			return cut, false, c.fail("last", top, NewErrLastNotFound())
 		}
 
 		ok, err = se.eval(last)
 		if err != nil {
-			return nil, err
+			return cut, false, c.fail("eval", top, err)
 		}
 
-		if ok {
-			done = append(done, top)
+		if ok && (onlyDepth <= 0 || top.depth == onlyDepth) {
+			state.solutions++
+
+			if !yield(top) {
+				return cut, true, nil
+			}
+
+			if se.limits.MaxSolutions > 0 && state.solutions >= se.limits.MaxSolutions {
+				return cut, false, NewErrLimitReached(LimitSolutions, se.limits.MaxSolutions)
+			}
 		}
 
 		nexts, err := se.nexts(ok, last)
 		if err != nil {
-			return nil, err
+			return cut, false, c.fail("nexts", top, err)
 		}
 
 		if len(nexts) == 0 {
 			continue
 		}
 
-		nexts, err = se.filter(ok, nexts)
+		nexts, err = c.filter(ok, top, nexts)
 		if err != nil {
-			return nil, err
+			return cut, false, c.fail("filter", top, err)
+		}
+
+		if len(nexts) == 0 {
+			continue
+		}
+
+		if maxDepth > 0 && top.depth >= maxDepth {
+			cut = true
+			continue
 		}
 
 		for _, next := range nexts {
-			topCopy := top.Copy().(E)
+			cost, err := se.edgeCost(last, next)
+			if err != nil {
+				return cut, false, c.fail("cost", top, err)
+			}
 
-			topCopy.Append(next)
+			if cost < 0 && bestFirst {
+				return cut, false, c.fail("cost", top, errors.New("negative cost in best-first search"))
+			}
+
+			item, err := c.extend(top.path, next)
+			if err != nil {
+				return cut, false, c.fail("copy", top, err)
+			}
 
-			S.Push(topCopy)
+			f.push(stackItem[P]{
+				path:  item,
+				depth: top.depth + 1,
+				cost:  top.cost + cost,
+			})
 		}
 	}
 
-	return done, nil
+	return cut, false, nil
 }
@@ -0,0 +1,151 @@
+package Slices
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// CostFunc is a function that returns the cost of an edge.
+//
+// Parameters:
+//   - from: The last element of the path.
+//   - to: The next element.
+//
+// Returns:
+//   - float64: The cost of going from from to to.
+//   - error: An error if the cost could not be computed.
+type CostFunc[T any] func(from, to T) (float64, error)
+
+// CostedPath is a path together with its total cost.
+type CostedPath[E any] struct {
+	// Path is the path.
+	Path E
+
+	// Cost is the total cost of the path.
+	Cost float64
+}
+
+// SetCost sets the function that returns the cost of an edge.
+//
+// Parameters:
+//   - cost: The cost function. If nil, every edge costs 1.
+func (se *StackEvaluator[T, E]) SetCost(cost CostFunc[T]) {
+	se.cost = cost
+}
+
+// edgeCost returns the cost of an edge.
+//
+// Parameters:
+//   - from: The last element of the path.
+//   - to: The next element.
+//
+// Returns:
+//   - float64: The cost of the edge.
+//   - error: An error if the cost could not be computed.
+func (se *StackEvaluator[T, E]) edgeCost(from, to T) (float64, error) {
+	if se.cost == nil {
+		return 1.0, nil
+	}
+
+	return se.cost(from, to)
+}
+
+// EvaluateWithCost is like EvaluateContext but also returns the total cost of
+// every solution.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - elem: The element to start the evaluation.
+//
+// Returns:
+//   - []*CostedPath[E]: The solutions, in the order in which they were found.
+//   - error: An error if the elements could not be evaluated.
+//
+// Errors:
+//   - same as EvaluateContext.
+//   - *ErrPath[E]: If the cost function fails.
+func (se *StackEvaluator[T, E]) EvaluateWithCost(ctx context.Context, elem T) ([]*CostedPath[E], error) {
+	var done []*CostedPath[E]
+
+	err := se.run(ctx, elem, se.mode, func(item stackItem[E]) bool {
+		done = append(done, &CostedPath[E]{
+			Path: item.path,
+			Cost: item.cost,
+		})
+
+		return true
+	})
+
+	return done, err
+}
+
+// KShortest returns the k solutions with the lowest total cost with a
+// best-first search.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - elem: The element to start the evaluation.
+//   - k: The number of solutions.
+//
+// Returns:
+//   - []*CostedPath[E]: At most k solutions, sorted by increasing total cost.
+//   - error: An error if the elements could not be evaluated.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If k is not positive.
+//   - same as EvaluateWithCost.
+//   - *ErrPath[E]: If the cost of an edge is negative.
+//
+// Behaviors:
+//   - The search mode of the evaluator is ignored.
+//   - The search stops as soon as k solutions are found.
+//   - The paths are not stopped at cycles; thus, if the elements reachable from
+//     elem contain a cycle and fewer than k solutions exist (or if a cycle costs
+//     nothing), the search only ends because of a limit, of the context, or of
+//     a path-aware filter that forbids cycles, such as SimplePathFilter.
+func (se *StackEvaluator[T, E]) KShortest(ctx context.Context, elem T, k int) ([]*CostedPath[E], error) {
+	if k <= 0 {
+		return nil, uc.NewErrInvalidParameter("k", errors.New("value must be positive"))
+	}
+
+	var done []*CostedPath[E]
+
+	err := se.run(ctx, elem, BestFirst, func(item stackItem[E]) bool {
+		done = append(done, &CostedPath[E]{
+			Path: item.path,
+			Cost: item.cost,
+		})
+
+		return len(done) < k
+	})
+
+	return done, err
+}
+
+// SortByCost sorts the paths by increasing total cost.
+//
+// Parameters:
+//   - paths: The paths to sort.
+//
+// Behaviors:
+//   - The sort is stable.
+//   - Nil paths are moved to the end.
+func SortByCost[E any](paths []*CostedPath[E]) {
+	slices.SortStableFunc(paths, func(a, b *CostedPath[E]) int {
+		if a == nil || b == nil {
+			if a == nil && b == nil {
+				return 0
+			} else if a == nil {
+				return 1
+			}
+
+			return -1
+		}
+
+		return cmp.Compare(a.Cost, b.Cost)
+	})
+}
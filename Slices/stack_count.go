@@ -0,0 +1,152 @@
+package Slices
+
+import (
+	"context"
+	"errors"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// Count counts the solutions without building the paths.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - elem: The element to start the evaluation.
+//
+// Returns:
+//   - int: The number of solutions.
+//   - error: An error if the evaluation failed or a limit was reached.
+//
+// Errors:
+//   - same as EvaluateContext, except that, if no path-aware filter is set,
+//     the Path of an *ErrPath[E] only holds the last element of the path.
+//
+// Behaviors:
+//   - On error, the number of solutions found so far is returned.
+//   - If a path-aware filter is set, the paths are built since the filter
+//     needs them.
+func (se *StackEvaluator[T, E]) Count(ctx context.Context, elem T) (int, error) {
+	if se.pathFilter != nil {
+		var count int
+
+		err := se.run(ctx, elem, se.mode, func(item stackItem[E]) bool {
+			count++
+			return true
+		})
+
+		return count, err
+	}
+
+	var count int
+
+	err := run(ctx, se, lastCarrier[T, E]{se: se}, elem, se.mode, func(item stackItem[T]) bool {
+		count++
+		return true
+	})
+
+	return count, err
+}
+
+// CountMemo counts the solutions of a StackEvaluator with dynamic programming,
+// assuming that the elements form a directed acyclic graph.
+//
+// Parameters:
+//   - ctx: The context of the evaluation.
+//   - se: The StackEvaluator.
+//   - elem: The element to start the evaluation.
+//   - key: The function that returns the key of an element. Elements with the
+//     same key must have the same solutions.
+//
+// Returns:
+//   - int: The number of solutions.
+//   - error: An error if the evaluation failed.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If se or key is nil, or if se has a
+//     path-aware filter.
+//   - *ErrCycle[T]: If the elements reachable from elem contain a cycle.
+//   - *ErrPath[E]: If the eval, nexts or filter functions fail on an element.
+//     The Path only holds that element.
+//   - the error of the context if it is done.
+//
+// Behaviors:
+//   - Every element is evaluated at most once per key.
+//   - The limits and the search mode of se are ignored.
+func CountMemo[T any, E Laster[T], K comparable](ctx context.Context, se *StackEvaluator[T, E], elem T, key func(elem T) K) (int, error) {
+	if se == nil {
+		return 0, uc.NewErrNilParameter("se")
+	} else if key == nil {
+		return 0, uc.NewErrNilParameter("key")
+	} else if se.pathFilter != nil {
+		return 0, uc.NewErrInvalidParameter("se", errors.New("path-aware filters are not supported"))
+	}
+
+	lc := lastCarrier[T, E]{se: se}
+
+	memo := make(map[K]int)
+	inProgress := make(map[K]bool)
+
+	var count func(elem T, depth int) (int, error)
+
+	count = func(elem T, depth int) (int, error) {
+		err := ctx.Err()
+		if err != nil {
+			return 0, err
+		}
+
+		item := stackItem[T]{
+			path:  elem,
+			depth: depth,
+		}
+
+		k := key(elem)
+
+		c, ok := memo[k]
+		if ok {
+			return c, nil
+		}
+
+		if inProgress[k] {
+			return 0, NewErrCycle(elem)
+		}
+
+		inProgress[k] = true
+		defer delete(inProgress, k)
+
+		ok, err = se.eval(elem)
+		if err != nil {
+			return 0, lc.fail("eval", item, err)
+		}
+
+		if ok {
+			c = 1
+		}
+
+		nexts, err := se.nexts(ok, elem)
+		if err != nil {
+			return 0, lc.fail("nexts", item, err)
+		}
+
+		if len(nexts) > 0 {
+			nexts, err = se.filter(ok, nexts)
+			if err != nil {
+				return 0, lc.fail("filter", item, err)
+			}
+		}
+
+		for _, next := range nexts {
+			tmp, err := count(next, depth+1)
+			if err != nil {
+				return 0, err
+			}
+
+			c += tmp
+		}
+
+		memo[k] = c
+
+		return c, nil
+	}
+
+	return count(elem, 1)
+}
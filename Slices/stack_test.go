@@ -0,0 +1,296 @@
+package Slices
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// graphEvaluator creates a StackEvaluator over a graph where the solutions
+// are the paths that end on a goal.
+//
+// Parameters:
+//   - t: The test.
+//   - edges: The successors of every node.
+//   - goals: The goal nodes.
+//
+// Returns:
+//   - *StackEvaluator[string, *PathLaster[string]]: The new StackEvaluator.
+func graphEvaluator(t *testing.T, edges map[string][]string, goals ...string) *StackEvaluator[string, *PathLaster[string]] {
+	t.Helper()
+
+	eval := func(elem string) (bool, error) {
+		return slices.Contains(goals, elem), nil
+	}
+
+	nexts := func(wasDone bool, elem string) ([]string, error) {
+		return edges[elem], nil
+	}
+
+	se, err := NewStackEvaluator[string, *PathLaster[string]](eval, nexts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return se
+}
+
+// pathsOf returns the elements of every path.
+//
+// Parameters:
+//   - paths: The paths.
+//
+// Returns:
+//   - [][]string: The elements of every path.
+func pathsOf(paths []*PathLaster[string]) [][]string {
+	elems := make([][]string, 0, len(paths))
+
+	for _, path := range paths {
+		elems = append(elems, path.Slice())
+	}
+
+	return elems
+}
+
+// TestStackEvaluatorModes checks the order of the solutions in every search mode.
+func TestStackEvaluatorModes(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"c", "b"},
+		"b": {"d"},
+	}
+
+	tests := []struct {
+		mode SearchMode
+		want [][]string
+	}{
+		{DepthFirst, [][]string{{"a", "b", "d"}, {"a", "c"}}},
+		{BreadthFirst, [][]string{{"a", "c"}, {"a", "b", "d"}}},
+		{IterativeDeepening, [][]string{{"a", "c"}, {"a", "b", "d"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.mode.String(), func(t *testing.T) {
+			se := graphEvaluator(t, edges, "c", "d")
+			se.SetMode(test.mode)
+
+			paths, err := se.Evaluate("a")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			got := pathsOf(paths)
+
+			if !slices.EqualFunc(got, test.want, slices.Equal) {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+// TestStackEvaluatorUnknownMode checks that an unknown mode is ignored.
+func TestStackEvaluatorUnknownMode(t *testing.T) {
+	se := graphEvaluator(t, nil)
+
+	se.SetMode(BreadthFirst)
+	se.SetMode(SearchMode(-1))
+
+	if se.mode != BreadthFirst {
+		t.Errorf("expected mode %v, got %v", BreadthFirst, se.mode)
+	}
+
+	if got := SearchMode(-1).String(); got != "SearchMode(-1)" {
+		t.Errorf("expected %q, got %q", "SearchMode(-1)", got)
+	}
+}
+
+// TestStackEvaluatorCountError checks that Count reports the same error type
+// with or without the paths.
+func TestStackEvaluatorCountError(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b", "c"},
+	}
+
+	se := graphEvaluator(t, edges, "b", "c")
+
+	count, err := se.Count(context.Background(), "a")
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 solutions and no error, got %d and %v", count, err)
+	}
+
+	se.SetFilter(func(wasDone bool, nexts []string) ([]string, error) {
+		return nil, errors.New("failure")
+	})
+
+	_, err = se.Count(context.Background(), "a")
+
+	var pathErr *ErrPath[*PathLaster[string]]
+
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("expected an *ErrPath, got %v", err)
+	}
+
+	if got := pathErr.Path.Slice(); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("expected path [a], got %q", got)
+	}
+}
+
+// TestStackEvaluatorKShortest checks KShortest and the best-first order on the
+// following graph, where d is the goal:
+//
+//	a -1-> b, a -4-> c, b -2-> c, b -5-> d, c -1-> d
+//
+// The paths to d are a-b-c-d (4), a-c-d (5) and a-b-d (6).
+func TestStackEvaluatorKShortest(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"c", "d"},
+		"c": {"d"},
+	}
+
+	costs := map[[2]string]float64{
+		{"a", "b"}: 1,
+		{"a", "c"}: 4,
+		{"b", "c"}: 2,
+		{"b", "d"}: 5,
+		{"c", "d"}: 1,
+	}
+
+	se := graphEvaluator(t, edges, "d")
+
+	se.SetCost(func(from, to string) (float64, error) {
+		return costs[[2]string{from, to}], nil
+	})
+
+	paths, err := se.KShortest(context.Background(), "a", 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []struct {
+		path []string
+		cost float64
+	}{
+		{[]string{"a", "b", "c", "d"}, 4},
+		{[]string{"a", "c", "d"}, 5},
+	}
+
+	if len(paths) != len(want) {
+		t.Fatalf("expected %d paths, got %d", len(want), len(paths))
+	}
+
+	for i, w := range want {
+		if got := paths[i].Path.Slice(); !slices.Equal(got, w.path) || paths[i].Cost != w.cost {
+			t.Errorf("path %d: expected %q (%v), got %q (%v)", i, w.path, w.cost, got, paths[i].Cost)
+		}
+	}
+
+	se.SetMode(BestFirst)
+
+	all, err := se.Evaluate("a")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got := pathsOf(all)
+	order := [][]string{{"a", "b", "c", "d"}, {"a", "c", "d"}, {"a", "b", "d"}}
+
+	if !slices.EqualFunc(got, order, slices.Equal) {
+		t.Errorf("expected %q, got %q", order, got)
+	}
+}
+
+// TestSimplePathFilter checks that SimplePathFilter cuts the cycles.
+func TestSimplePathFilter(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b"},
+		"b": {"a", "c", "b"},
+	}
+
+	se := graphEvaluator(t, edges, "c")
+	se.SetPathFilter(SimplePathFilter[string, *PathLaster[string]])
+
+	paths, err := se.Evaluate("a")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got := pathsOf(paths)
+	want := [][]string{{"a", "b", "c"}}
+
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	filtered, _ := SimplePathFilter(false, NewSliceLaster([]string{"a", "b"}), 2, []string{"a", "c"})
+
+	if !slices.Equal(filtered, []string{"c"}) {
+		t.Errorf("expected [c], got %q", filtered)
+	}
+}
+
+// TestStackEvaluatorKShortestCycle checks that KShortest ends on a cyclic graph
+// with fewer than k solutions when the path filter cuts the cycles.
+func TestStackEvaluatorKShortestCycle(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b"},
+		"b": {"a", "c"},
+	}
+
+	se := graphEvaluator(t, edges, "c")
+	se.SetPathFilter(SimplePathFilter[string, *PathLaster[string]])
+
+	paths, err := se.KShortest(context.Background(), "a", 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(paths) != 1 || paths[0].Cost != 2 {
+		t.Errorf("expected one path of cost 2, got %d paths", len(paths))
+	}
+}
+
+// TestCountMemoError checks that CountMemo reports the same error type as
+// Count and honors the context.
+func TestCountMemoError(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+	}
+
+	se := graphEvaluator(t, edges, "d")
+
+	key := func(elem string) string {
+		return elem
+	}
+
+	count, err := CountMemo(context.Background(), se, "a", key)
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 solutions and no error, got %d and %v", count, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = CountMemo(ctx, se, "a", key)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	se.SetFilter(func(wasDone bool, nexts []string) ([]string, error) {
+		return nil, errors.New("failure")
+	})
+
+	_, err = CountMemo(context.Background(), se, "a", key)
+
+	var pathErr *ErrPath[*PathLaster[string]]
+
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("expected an *ErrPath, got %v", err)
+	}
+
+	if got := pathErr.Path.Slice(); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("expected path [a], got %q", got)
+	}
+}
@@ -0,0 +1,261 @@
+package Slices
+
+import (
+	"errors"
+	"strconv"
+
+	uc "github.com/PlayerR9/lib_units/common"
+)
+
+// ZipMode is the behavior of a zip when the streams have different lengths.
+type ZipMode int
+
+const (
+	// ZipShortest stops as soon as one of the streams is exhausted.
+	ZipShortest ZipMode = iota
+
+	// ZipLongest stops when all the streams are exhausted. Missing elements
+	// are replaced by their zero value.
+	ZipLongest
+
+	// ZipStrict stops with an error of type *ErrLengthMismatch if the streams
+	// do not end at the same time.
+	ZipStrict
+)
+
+// String implements the fmt.Stringer interface.
+func (m ZipMode) String() string {
+	names := [...]string{
+		"shortest",
+		"longest",
+		"strict",
+	}
+
+	if m < 0 || int(m) >= len(names) {
+		return "ZipMode(" + strconv.Itoa(int(m)) + ")"
+	}
+
+	return names[m]
+}
+
+// zipped is an iterable created from a function that returns its iterator.
+type zipped[T any] struct {
+	// iterFn is the function that returns a new iterator.
+	iterFn func() uc.Iterater[T]
+}
+
+// Iterator implements the common.Iterable interface.
+func (z *zipped[T]) Iterator() uc.Iterater[T] {
+	return z.iterFn()
+}
+
+// zipIterator is an iterator over aligned streams.
+type zipIterator[T any] struct {
+	// iters are the iterators over the streams.
+	iters []uc.Iterater[any]
+
+	// mode is the behavior when the streams have different lengths.
+	mode ZipMode
+
+	// build creates an element from the values of the streams.
+	build func(values []any) T
+
+	// index is the index of the next element.
+	index int
+
+	// done is true if the iterator is exhausted.
+	done bool
+}
+
+// Consume implements the common.Iterater interface.
+//
+// Errors:
+//   - *common.ErrExhaustedIter: If the iterator is exhausted.
+//   - *ErrLengthMismatch: If the mode is ZipStrict and the streams do not end
+//     at the same time.
+//   - any other error returned by the streams.
+func (zi *zipIterator[T]) Consume() (T, error) {
+	if zi.done || len(zi.iters) == 0 {
+		return *new(T), uc.NewErrExhaustedIter()
+	}
+
+	values := make([]any, len(zi.iters))
+	var exhausted int
+
+	for i, iter := range zi.iters {
+		value, err := iter.Consume()
+		if err == nil {
+			values[i] = value
+			continue
+		}
+
+		ok := uc.Is[*uc.ErrExhaustedIter](err)
+		if !ok {
+			return *new(T), err
+		}
+
+		exhausted++
+	}
+
+	if exhausted == 0 {
+		zi.index++
+
+		return zi.build(values), nil
+	}
+
+	if exhausted == len(zi.iters) {
+		zi.done = true
+
+		return *new(T), uc.NewErrExhaustedIter()
+	}
+
+	switch zi.mode {
+	case ZipLongest:
+		zi.index++
+
+		return zi.build(values), nil
+	case ZipStrict:
+		zi.done = true
+
+		return *new(T), NewErrLengthMismatch(zi.index)
+	default:
+		zi.done = true
+
+		return *new(T), uc.NewErrExhaustedIter()
+	}
+}
+
+// Restart implements the common.Iterater interface.
+func (zi *zipIterator[T]) Restart() {
+	for _, iter := range zi.iters {
+		iter.Restart()
+	}
+
+	zi.index = 0
+	zi.done = false
+}
+
+// anyIterator is an iterator that erases the type of another iterator.
+type anyIterator[T any] struct {
+	// iter is the underlying iterator.
+	iter uc.Iterater[T]
+}
+
+// Consume implements the common.Iterater interface.
+func (ai *anyIterator[T]) Consume() (any, error) {
+	return ai.iter.Consume()
+}
+
+// Restart implements the common.Iterater interface.
+func (ai *anyIterator[T]) Restart() {
+	ai.iter.Restart()
+}
+
+// valueOf returns the value as a T, or its zero value if it is missing.
+//
+// Parameters:
+//   - value: The value.
+//
+// Returns:
+//   - T: The value as a T.
+func valueOf[T any](value any) T {
+	if value == nil {
+		return *new(T)
+	}
+
+	return value.(T)
+}
+
+// Zip2 zips two streams into one stream of pairs.
+//
+// Parameters:
+//   - first: The first stream.
+//   - second: The second stream.
+//   - mode: The behavior when the streams have different lengths.
+//
+// Returns:
+//   - uc.Iterable[uc.Pair[A, B]]: The zipped stream.
+//   - error: An error of type *common.ErrInvalidParameter if first or second is
+//     nil, or if mode is unknown.
+//
+// Behaviors:
+//   - The zipped stream can be returned by the Iterator method of a LeafEvaluater.
+func Zip2[A, B any](first uc.Iterable[A], second uc.Iterable[B], mode ZipMode) (uc.Iterable[uc.Pair[A, B]], error) {
+	if first == nil {
+		return nil, uc.NewErrNilParameter("first")
+	} else if second == nil {
+		return nil, uc.NewErrNilParameter("second")
+	} else if mode < ZipShortest || mode > ZipStrict {
+		return nil, uc.NewErrInvalidParameter("mode", errors.New("unknown mode "+mode.String()))
+	}
+
+	z := &zipped[uc.Pair[A, B]]{
+		iterFn: func() uc.Iterater[uc.Pair[A, B]] {
+			return &zipIterator[uc.Pair[A, B]]{
+				iters: []uc.Iterater[any]{
+					&anyIterator[A]{iter: first.Iterator()},
+					&anyIterator[B]{iter: second.Iterator()},
+				},
+				mode: mode,
+				build: func(values []any) uc.Pair[A, B] {
+					return uc.NewPair(valueOf[A](values[0]), valueOf[B](values[1]))
+				},
+			}
+		},
+	}
+
+	return z, nil
+}
+
+// ZipN zips several streams of the same type into one stream of slices.
+//
+// Parameters:
+//   - mode: The behavior when the streams have different lengths.
+//   - streams: The streams to zip.
+//
+// Returns:
+//   - uc.Iterable[[]E]: The zipped stream. The i-th value of each slice comes
+//     from the i-th stream.
+//   - error: An error of type *common.ErrInvalidParameter if one of the streams
+//     is nil, or if mode is unknown.
+//
+// Behaviors:
+//   - The zipped stream can be returned by the Iterator method of a LeafEvaluater.
+//   - If no stream is given, the zipped stream is empty.
+func ZipN[E any](mode ZipMode, streams ...uc.Iterable[E]) (uc.Iterable[[]E], error) {
+	if mode < ZipShortest || mode > ZipStrict {
+		return nil, uc.NewErrInvalidParameter("mode", errors.New("unknown mode "+mode.String()))
+	}
+
+	for _, stream := range streams {
+		if stream == nil {
+			return nil, uc.NewErrNilParameter("streams")
+		}
+	}
+
+	z := &zipped[[]E]{
+		iterFn: func() uc.Iterater[[]E] {
+			iters := make([]uc.Iterater[any], 0, len(streams))
+
+			for _, stream := range streams {
+				iters = append(iters, &anyIterator[E]{iter: stream.Iterator()})
+			}
+
+			return &zipIterator[[]E]{
+				iters: iters,
+				mode:  mode,
+				build: func(values []any) []E {
+					elems := make([]E, 0, len(values))
+
+					for _, value := range values {
+						elems = append(elems, valueOf[E](value))
+					}
+
+					return elems
+				},
+			}
+		},
+	}
+
+	return z, nil
+}
@@ -1,6 +1,6 @@
 module github.com/PlayerR9/evaluations
 
-go 1.22.5
+go 1.23
 
 require github.com/PlayerR9/MyGoLib v0.4.9
 